@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// run executes the command line args against a fresh store in a temporary
+// directory and returns what it printed.
+func run(t *testing.T, db string, args ...string) (string, error) {
+	t.Helper()
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("SUPER_SNAKE_STORAGE", "")
+
+	if db == "" {
+		db = filepath.Join(t.TempDir(), "scores.db")
+	}
+
+	// Flags keep their values between runs of the same command tree.
+	resetFlags(rootCmd)
+	t.Cleanup(func() {
+		if app != nil {
+			app.Close()
+			app = nil
+		}
+	})
+
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetErr(&out)
+	rootCmd.SetArgs(append(args, "--db", db))
+
+	err := rootCmd.Execute()
+	return out.String(), err
+}
+
+func resetFlags(cmd *cobra.Command) {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		flag.Value.Set(flag.DefValue)
+		flag.Changed = false
+	})
+
+	for _, child := range cmd.Commands() {
+		resetFlags(child)
+	}
+}
+
+func TestDeathsFlags(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		// wantErr is part of the error the command should fail with.
+		wantErr string
+	}{
+		{name: "defaults", args: []string{"deaths"}},
+		{name: "every cell", args: []string{"deaths", "--limit", "0"}},
+		{name: "negative limit", args: []string{"deaths", "--limit", "-1"}, wantErr: "--limit"},
+		{name: "known cause", args: []string{"deaths", "--cause", "pillar"}},
+		{name: "unknown cause", args: []string{"deaths", "--cause", "lava"}, wantErr: "--cause"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out, err := run(t, "", test.args...)
+			if test.wantErr == "" {
+				if err != nil {
+					t.Fatalf("%v: %v\n%s", test.args, err, out)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Fatalf("%v = %v, want an error about %s", test.args, err, test.wantErr)
+			}
+		})
+	}
+}
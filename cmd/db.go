@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"bufio"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/the-Jinxist/golang_snake_game/internal"
+	"github.com/the-Jinxist/golang_snake_game/tui/game"
+)
+
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Manage the scores database",
+}
+
+// requireDB returns the SQLite database the db commands work on. It is
+// opened as it is on disk, without migrating it.
+func requireDB() (*sql.DB, error) {
+	if app.DB == nil {
+		return nil, errors.New("db commands only work with --storage sqlite")
+	}
+
+	return app.DB, nil
+}
+
+// requireMigratedDB returns the database with its schema brought up to date,
+// for commands that read or delete rows.
+func requireMigratedDB() (*sql.DB, error) {
+	db, err := requireDB()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := internal.Migrate(db); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+var dbMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Apply pending schema migrations",
+	Long:  `Migrations are applied automatically when the game starts. Use --status to list every migration and when it was applied.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := requireDB()
+		if err != nil {
+			return err
+		}
+
+		statuses, err := internal.MigrationStatuses(db)
+		if err != nil {
+			return err
+		}
+
+		showStatus, _ := cmd.Flags().GetBool("status")
+		if !showStatus {
+			// Migrate names the migration that failed, if one does.
+			if err := internal.Migrate(db); err != nil {
+				return err
+			}
+
+			for _, status := range statuses {
+				if !status.Applied {
+					fmt.Fprintf(cmd.OutOrStdout(), "applied %04d_%s\n", status.Version, status.Name)
+				}
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), "database is up to date")
+			return nil
+		}
+
+		for _, status := range statuses {
+			applied := "pending"
+			if status.Applied {
+				applied = "applied " + status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "%04d  %-20s %s\n", status.Version, status.Name, applied)
+		}
+
+		return nil
+	},
+}
+
+var dbBackupCmd = &cobra.Command{
+	Use:   "backup <path>",
+	Short: "Copy the database to a file",
+	Long:  `Write a consistent copy of the scores database to path. It is safe to run while a game is open; an existing file is never overwritten.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := requireDB()
+		if err != nil {
+			return err
+		}
+
+		if err := internal.Backup(cmd.Context(), db, args[0]); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "backed up to %s\n", args[0])
+		return nil
+	},
+}
+
+var dbPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Delete old scores that are not on the leaderboard",
+	Long: `Delete scores recorded before --older-than (a date like 2025-06-01 or an age like 90d) unless they are among the --keep-top highest.
+Replays and ended sessions without a score are removed with them. Scores of saved games are always kept.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		options := internal.PruneOptions{}
+		options.KeepTop, _ = cmd.Flags().GetInt("keep-top")
+		options.DryRun, _ = cmd.Flags().GetBool("dry-run")
+
+		if options.KeepTop < 0 {
+			return errors.New("--keep-top cannot be negative")
+		}
+
+		olderThan, _ := cmd.Flags().GetString("older-than")
+		cutoff, err := parseSince(olderThan, time.Now())
+		if err != nil {
+			return fmt.Errorf("--older-than: %w", err)
+		}
+
+		options.OlderThan = cutoff
+
+		db, err := requireMigratedDB()
+		if err != nil {
+			return err
+		}
+
+		result, err := internal.Prune(cmd.Context(), db, options)
+		if err != nil {
+			return err
+		}
+
+		verb := "deleted"
+		if options.DryRun {
+			verb = "would delete"
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "%s %d scores, %d replays and %d sessions\n", verb, result.Scores, result.Replays, result.Sessions)
+		if !options.DryRun {
+			vacuum(cmd, db)
+		}
+
+		return nil
+	},
+}
+
+var dbResetCmd = &cobra.Command{
+	Use:   "reset",
+	Short: "Delete every score, session, stat and achievement",
+	Long:  `Clear all recorded games and the saved game, keeping player profiles. Asks for confirmation unless --yes is passed; take a backup first with db backup.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if _, err := requireDB(); err != nil {
+			return err
+		}
+
+		if yes, _ := cmd.Flags().GetBool("yes"); !yes {
+			fmt.Fprint(cmd.OutOrStdout(), "This deletes every score, session, stat and achievement. Type \"reset\" to continue: ")
+
+			answer, _ := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+			if strings.TrimSpace(answer) != "reset" {
+				fmt.Fprintln(cmd.OutOrStdout(), "nothing was deleted")
+				return nil
+			}
+		}
+
+		// Migrating writes to the database too, so it waits for the
+		// confirmation.
+		db, err := requireMigratedDB()
+		if err != nil {
+			return err
+		}
+
+		if err := internal.Reset(cmd.Context(), db); err != nil {
+			return err
+		}
+
+		// Saved games point at sessions that no longer exist.
+		if err := game.DeleteSavedGames(app.StorePath); err != nil {
+			return err
+		}
+
+		fmt.Fprintln(cmd.OutOrStdout(), "database reset")
+		vacuum(cmd, db)
+		return nil
+	},
+}
+
+// vacuum reclaims the space freed by a deletion that has already been
+// committed, so a failure is only a warning.
+func vacuum(cmd *cobra.Command, db *sql.DB) {
+	if err := internal.Vacuum(cmd.Context(), db); err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "warning: the space could not be reclaimed: %s\n", err)
+	}
+}
+
+func init() {
+	dbMigrateCmd.Flags().Bool("status", false, "Show applied and pending migrations")
+
+	dbPruneCmd.Flags().Int("keep-top", 100, "Never delete the N highest scores")
+	dbPruneCmd.Flags().String("older-than", "90d", "Only delete scores recorded before a date (2025-06-01) or age (90d)")
+	dbPruneCmd.Flags().Bool("dry-run", false, "Report what would be deleted without deleting it")
+
+	dbResetCmd.Flags().BoolP("yes", "y", false, "Do not ask for confirmation")
+
+	dbCmd.AddCommand(dbMigrateCmd)
+	dbCmd.AddCommand(dbBackupCmd)
+	dbCmd.AddCommand(dbPruneCmd)
+	dbCmd.AddCommand(dbResetCmd)
+	rootCmd.AddCommand(dbCmd)
+}
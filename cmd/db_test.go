@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/the-Jinxist/golang_snake_game/internal"
+)
+
+func TestDBReset(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		answer string
+		// wantReset is whether the scores are gone afterwards.
+		wantReset bool
+	}{
+		{name: "confirmed", answer: "reset\n", wantReset: true},
+		{name: "declined", answer: "no\n"},
+		{name: "no answer"},
+		{name: "--yes", args: []string{"--yes"}, wantReset: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			db := filepath.Join(dir, "scores.db")
+
+			fixture := filepath.Join(dir, "fixture.json")
+			err := os.WriteFile(fixture, []byte(`[{"user": "alice", "session": "a", "value": 30, "mode": "classic"}]`), 0o644)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := run(t, db, "scores", "import", fixture); err != nil {
+				t.Fatal(err)
+			}
+
+			rootCmd.SetIn(strings.NewReader(test.answer))
+			t.Cleanup(func() { rootCmd.SetIn(nil) })
+
+			if out, err := run(t, db, append([]string{"db", "reset"}, test.args...)...); err != nil {
+				t.Fatalf("%v\n%s", err, out)
+			}
+
+			out, err := run(t, db, "scores", "list", "--output", "json")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if reset := !strings.Contains(out, `"user": "alice"`); reset != test.wantReset {
+				t.Fatalf("scores after db reset:\n%s\nwant reset: %t", out, test.wantReset)
+			}
+		})
+	}
+}
+
+// TestDBResetDeclinedLeavesSchema checks that declining a reset does not
+// migrate the database either.
+func TestDBResetDeclinedLeavesSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scores.db")
+
+	rootCmd.SetIn(strings.NewReader("no\n"))
+	t.Cleanup(func() { rootCmd.SetIn(nil) })
+
+	if out, err := run(t, path, "db", "reset"); err != nil {
+		t.Fatalf("%v\n%s", err, out)
+	}
+
+	db, err := internal.OpenDB(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	var tables int
+	if err := db.QueryRow(`select count(*) from sqlite_master where type = 'table'`).Scan(&tables); err != nil {
+		t.Fatal(err)
+	}
+
+	if tables != 0 {
+		t.Fatalf("database has %d tables after a declined reset, want none", tables)
+	}
+}
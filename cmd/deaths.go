@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/the-Jinxist/golang_snake_game/internal"
+)
+
+var deathsCmd = &cobra.Command{
+	Use:   "deaths",
+	Short: "Show the board cells where most runs end",
+	Long:  `List the cells that killed the most players, grouped by level and cause. Use --cause pillar to find the deadliest pillars.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		query := internal.DeathQuery{}
+
+		if cmd.Flags().Changed("level") {
+			level, _ := cmd.Flags().GetInt("level")
+			query.Level = &level
+		}
+
+		cause, _ := cmd.Flags().GetString("cause")
+		query.Cause = internal.DeathCause(cause)
+		if query.Cause != internal.DeathNone && !slices.Contains(internal.DeathCauses, query.Cause) {
+			return fmt.Errorf("--cause: unknown cause %q, expected one of %s", cause, joinCauses(internal.DeathCauses))
+		}
+
+		query.Limit, _ = cmd.Flags().GetInt("limit")
+		if query.Limit < 0 {
+			return fmt.Errorf("--limit: must be 0 or more, got %d", query.Limit)
+		}
+
+		hotspots, err := app.Scores.GetDeathHotspots(context.Background(), query)
+		if err != nil {
+			return err
+		}
+
+		if len(hotspots) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "no deaths recorded yet")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "LEVEL\tCAUSE\tCELL\tDEATHS")
+		for _, hotspot := range hotspots {
+			fmt.Fprintf(w, "%d\t%s\t%d,%d\t%d\n", hotspot.Level, hotspot.Cause, hotspot.X, hotspot.Y, hotspot.Deaths)
+		}
+
+		return w.Flush()
+	},
+}
+
+func joinCauses(causes []internal.DeathCause) string {
+	names := make([]string, 0, len(causes))
+	for _, cause := range causes {
+		names = append(names, string(cause))
+	}
+
+	return strings.Join(names, ", ")
+}
+
+func init() {
+	deathsCmd.Flags().Int("level", 0, "Only show deaths on this level")
+	deathsCmd.Flags().String("cause", "", "Only show deaths with this cause ("+joinCauses(internal.DeathCauses)+")")
+	deathsCmd.Flags().Int("limit", 10, "Maximum number of cells to show, or 0 for all of them")
+
+	rootCmd.AddCommand(deathsCmd)
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/the-Jinxist/golang_snake_game/internal"
+	"github.com/the-Jinxist/golang_snake_game/server"
+	"github.com/the-Jinxist/golang_snake_game/tui/game"
+)
+
+var leaderboardServerCmd = &cobra.Command{
+	Use:   "leaderboard-server",
+	Short: "Serve a shared leaderboard over HTTP",
+	Long: `Serve the scores database as a REST API so several players can share one leaderboard.
+Point the game at it with --leaderboard-url http://host:port.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		addr, _ := cmd.Flags().GetString("addr")
+
+		// Always serve the local store, even if --leaderboard-url was
+		// passed, so a server never proxies to another one.
+		scores := app.Scores
+		if remote, ok := scores.(*internal.RemoteScoreService); ok {
+			scores = remote.ScoreService
+		}
+
+		srv := &http.Server{
+			Addr:              addr,
+			Handler:           server.New(scores, game.ReplayVerifier{}),
+			ReadHeaderTimeout: 5 * time.Second,
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "leaderboard server listening on %s\n", addr)
+		return srv.ListenAndServe()
+	},
+}
+
+func init() {
+	leaderboardServerCmd.Flags().String("addr", ":8080", "Address to listen on")
+
+	rootCmd.AddCommand(leaderboardServerCmd)
+}
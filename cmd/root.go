@@ -4,29 +4,76 @@ Copyright © 2025 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
-	"log"
+	"fmt"
 	"os"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 	"github.com/the-Jinxist/golang_snake_game/internal"
 	"github.com/the-Jinxist/golang_snake_game/tui"
-
-	_ "github.com/mattn/go-sqlite3"
+	"github.com/the-Jinxist/golang_snake_game/tui/theme"
 )
 
+// app is the game instance the running command works against, built from
+// the root flags before any command runs.
+var app *internal.App
+
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
 	Use:   "super_snake",
 	Short: "The best terminal snake game written in Go",
 	Long:  `Run the super_snake command to start playing the classic snake game in your terminal!`,
-	Run: func(cmd *cobra.Command, args []string) {
-		p := tea.NewProgram(tui.NewModel(), tea.WithAltScreen())
-		if _, err := p.Run(); err != nil {
-			log.Fatal(err)
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		options := internal.Options{}
+		options.DBPath, _ = cmd.Flags().GetString("db")
+
+		storage, _ := cmd.Flags().GetString("storage")
+		if storage == "" {
+			storage = os.Getenv("SUPER_SNAKE_STORAGE")
 		}
 
-		os.Exit(1)
+		backend, err := internal.ParseStorageBackend(storage)
+		if err != nil {
+			return err
+		}
+
+		options.Storage = backend
+		options.LeaderboardURL, _ = cmd.Flags().GetString("leaderboard-url")
+
+		// The db commands look at and change the schema themselves, so
+		// they get the database exactly as it is on disk.
+		options.Maintenance = cmd.HasParent() && cmd.Parent() == dbCmd
+
+		// --player and --guest pick who plays and where scores go, so they
+		// only apply when launching the game itself.
+		if !cmd.HasParent() {
+			options.Player, _ = cmd.Flags().GetString("player")
+			options.Guest, _ = cmd.Flags().GetBool("guest")
+
+			// A broken database should not stop anyone playing; the game
+			// runs without saving and says so instead.
+			options.FallbackToMemory = true
+		}
+
+		app, err = internal.NewApp(options)
+		return err
+	},
+	PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+		return app.Close()
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if name, _ := cmd.Flags().GetString("theme"); name != "" {
+			if _, ok := theme.Get(name); !ok {
+				return fmt.Errorf("--theme: unknown theme %q, expected one of %s", name, strings.Join(theme.Names(), ", "))
+			}
+
+			app.ThemeOverride = name
+		}
+
+		p := tea.NewProgram(tui.NewModel(app), tea.WithAltScreen())
+		_, err := p.Run()
+		return err
 	},
 }
 
@@ -38,7 +85,11 @@ func Execute() {
 }
 
 func init() {
-	internal.IntializeConfigs()
-
+	rootCmd.PersistentFlags().String("storage", "", "Where to keep scores: sqlite or json (defaults to $SUPER_SNAKE_STORAGE, then sqlite)")
+	rootCmd.PersistentFlags().String("db", "", "Path to the scores database or JSON store (defaults to $SUPER_SNAKE_DB or $XDG_DATA_HOME/super_snake/scores.db; for --storage json, $SUPER_SNAKE_JSON_STORE or scores.json there)")
+	rootCmd.PersistentFlags().String("leaderboard-url", "", "URL of a shared leaderboard server to submit scores to and read boards from")
+	rootCmd.Flags().String("player", "", "Name of the player profile to play as (defaults to the last active profile)")
+	rootCmd.Flags().Bool("guest", false, "Play without saving anything to disk; scores last until you quit")
+	rootCmd.Flags().String("theme", "", "Colour theme to play with instead of the one in settings: "+strings.Join(theme.Names(), ", "))
 	rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 }
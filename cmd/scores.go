@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/the-Jinxist/golang_snake_game/internal"
+)
+
+var scoresCmd = &cobra.Command{
+	Use:   "scores",
+	Short: "Export, import and query recorded scores",
+}
+
+var scoresListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List scores without launching the game",
+	Long:  `Print the standings, highest score first. Filter with --player, --level and --since (a date like 2025-06-01 or an age like 7d or 12h), page with --limit and --offset, and choose --output table or json.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		query := internal.ScoreQuery{}
+
+		query.Player, _ = cmd.Flags().GetString("player")
+		query.Limit, _ = cmd.Flags().GetInt("limit")
+		if query.Limit < 0 {
+			return fmt.Errorf("--limit: must be 0 or more, got %d", query.Limit)
+		}
+
+		query.Offset, _ = cmd.Flags().GetInt("offset")
+		if query.Offset < 0 {
+			return fmt.Errorf("--offset: must be 0 or more, got %d", query.Offset)
+		}
+
+		if cmd.Flags().Changed("level") {
+			level, _ := cmd.Flags().GetInt("level")
+			query.Level = &level
+		}
+
+		if since, _ := cmd.Flags().GetString("since"); since != "" {
+			parsed, err := parseSince(since, time.Now())
+			if err != nil {
+				return fmt.Errorf("--since: %w", err)
+			}
+
+			query.Since = parsed
+		}
+
+		scores, err := app.Scores.QueryScores(context.Background(), query)
+		if err != nil {
+			return err
+		}
+
+		output, _ := cmd.Flags().GetString("output")
+		switch output {
+		case "json":
+			return internal.WriteScores(cmd.OutOrStdout(), internal.FormatJSON, scores)
+		case "table":
+			return writeScoresTable(cmd.OutOrStdout(), scores)
+		default:
+			return fmt.Errorf("unsupported output %q, use table or json", output)
+		}
+	},
+}
+
+func writeScoresTable(w io.Writer, scores []internal.Score) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "RANK\tPLAYER\tSCORE\tLEVEL\tMODE\tDURATION\tDATE\tVERIFIED")
+	for _, score := range scores {
+		verified := "no"
+		if score.Verification == internal.Verified {
+			verified = "yes"
+		}
+
+		fmt.Fprintf(tw, "%d\t%s\t%d\t%d\t%s\t%s\t%s\t%s\n",
+			score.Rank, score.DisplayName(), score.Value, score.Level, score.Mode,
+			score.Duration.Round(time.Second), score.CreatedAt.Local().Format("2006-01-02 15:04"), verified,
+		)
+	}
+
+	return tw.Flush()
+}
+
+// parseSince accepts a date (2006-01-02), an RFC 3339 timestamp, or an age
+// such as 12h or 7d counted back from now.
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err == nil {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+
+	if age, err := time.ParseDuration(value); err == nil {
+		return now.Add(-age), nil
+	}
+
+	if date, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return date, nil
+	}
+
+	if timestamp, err := time.Parse(time.RFC3339, value); err == nil {
+		return timestamp, nil
+	}
+
+	return time.Time{}, fmt.Errorf("cannot parse %q, use a date like 2025-06-01 or an age like 7d", value)
+}
+
+var scoresExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export every score as JSON or CSV",
+	Long:  `Write all recorded scores to stdout, or to the file given with --output, so they can be backed up or merged into another machine's leaderboard.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+
+		scores, err := app.Scores.QueryScores(context.Background(), internal.ScoreQuery{})
+		if err != nil {
+			return err
+		}
+
+		var w io.Writer = cmd.OutOrStdout()
+		if output != "" {
+			file, err := os.Create(output)
+			if err != nil {
+				return err
+			}
+
+			defer file.Close()
+			w = file
+		}
+
+		return internal.WriteScores(w, format, scores)
+	},
+}
+
+var scoresImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import scores from a JSON or CSV export",
+	Long:  `Merge scores exported from another machine. Scores whose session already exists are skipped, so importing the same file twice is safe.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		if format == "" {
+			format = strings.TrimPrefix(strings.ToLower(filepath.Ext(args[0])), ".")
+		}
+
+		file, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+
+		defer file.Close()
+
+		scores, err := internal.ReadScores(file, format)
+		if err != nil {
+			return err
+		}
+
+		imported, err := app.Scores.ImportScores(context.Background(), scores)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "imported %d of %d scores (%d already present)\n", imported, len(scores), len(scores)-imported)
+		return nil
+	},
+}
+
+func init() {
+	scoresListCmd.Flags().String("player", "", "Only list scores by this player")
+	scoresListCmd.Flags().Int("level", 0, "Only list scores that reached this level")
+	scoresListCmd.Flags().String("since", "", "Only list scores recorded since a date (2025-06-01) or age (7d, 12h)")
+	scoresListCmd.Flags().Int("limit", 10, "Maximum number of scores to list (0 for all)")
+	scoresListCmd.Flags().Int("offset", 0, "Number of scores to skip before listing, to page through the standings")
+	scoresListCmd.Flags().String("output", "table", "Output format: table or json")
+
+	scoresExportCmd.Flags().String("format", internal.FormatJSON, "Output format: json or csv")
+	scoresExportCmd.Flags().StringP("output", "o", "", "Write to this file instead of stdout")
+
+	scoresImportCmd.Flags().String("format", "", "Input format: json or csv (defaults to the file extension)")
+
+	scoresCmd.AddCommand(scoresListCmd, scoresExportCmd, scoresImportCmd)
+	rootCmd.AddCommand(scoresCmd)
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestScoresExportImport(t *testing.T) {
+	for _, format := range []string{"json", "csv"} {
+		t.Run(format, func(t *testing.T) {
+			dir := t.TempDir()
+			from, to := filepath.Join(dir, "from.db"), filepath.Join(dir, "to.db")
+
+			fixture := filepath.Join(dir, "fixture.json")
+			err := os.WriteFile(fixture, []byte(`[
+				{"user": "alice", "session": "a", "value": 30, "level": 1, "mode": "classic", "created_at": "2026-03-04T05:06:07Z"},
+				{"user": "bob", "session": "b", "value": 20, "level": 2, "mode": "classic", "created_at": "2026-03-04T05:06:07Z"}
+			]`), 0o644)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := run(t, from, "scores", "import", fixture); err != nil {
+				t.Fatal(err)
+			}
+
+			export := filepath.Join(dir, "export."+format)
+			if out, err := run(t, from, "scores", "export", "--format", format, "--output", export); err != nil {
+				t.Fatalf("%v\n%s", err, out)
+			}
+
+			// Importing the same export twice only adds the scores once.
+			for _, want := range []string{"imported 2 of 2 scores", "imported 0 of 2 scores (2 already present)"} {
+				out, err := run(t, to, "scores", "import", export)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if !strings.Contains(out, want) {
+					t.Fatalf("import printed %q, want %q", out, want)
+				}
+			}
+
+			out, err := run(t, to, "scores", "list", "--output", "json")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !strings.Contains(out, `"user": "alice"`) || !strings.Contains(out, `"user": "bob"`) {
+				t.Fatalf("imported board is missing scores:\n%s", out)
+			}
+		})
+	}
+}
+
+func TestScoresListPages(t *testing.T) {
+	db := filepath.Join(t.TempDir(), "scores.db")
+	fixture := filepath.Join(t.TempDir(), "fixture.json")
+	err := os.WriteFile(fixture, []byte(`[
+		{"user": "alice", "session": "a", "value": 30, "mode": "classic", "created_at": "2026-03-04T05:06:07Z"},
+		{"user": "bob", "session": "b", "value": 20, "mode": "classic", "created_at": "2026-03-04T05:06:07Z"},
+		{"user": "carol", "session": "c", "value": 10, "mode": "classic", "created_at": "2026-03-04T05:06:07Z"}
+	]`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := run(t, db, "scores", "import", fixture); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want []string
+		// wantErr is part of the error the command should fail with.
+		wantErr string
+	}{
+		{name: "first page", args: []string{"--limit", "2"}, want: []string{"alice", "bob"}},
+		{name: "second page", args: []string{"--limit", "2", "--offset", "2"}, want: []string{"carol"}},
+		{name: "everything after an offset", args: []string{"--limit", "0", "--offset", "1"}, want: []string{"bob", "carol"}},
+		{name: "negative limit", args: []string{"--limit", "-1"}, wantErr: "--limit"},
+		{name: "negative offset", args: []string{"--offset", "-1"}, wantErr: "--offset"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out, err := run(t, db, append([]string{"scores", "list"}, test.args...)...)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("%v = %v, want an error about %s", test.args, err, test.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("%v: %v\n%s", test.args, err, out)
+			}
+
+			var players []string
+			for _, line := range strings.Split(strings.TrimSpace(out), "\n")[1:] {
+				players = append(players, strings.Fields(line)[1])
+			}
+
+			if !slices.Equal(players, test.want) {
+				t.Fatalf("listed %v, want %v\n%s", players, test.want, out)
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+var _ AchievementService = &AchievementServiceImpl{}
+
+type GameEventKind string
+
+const (
+	EventLevelStarted GameEventKind = "level_started"
+	EventFoodEaten    GameEventKind = "food_eaten"
+	EventPaused       GameEventKind = "paused"
+	EventTick         GameEventKind = "tick"
+	EventGameOver     GameEventKind = "game_over"
+)
+
+// GameEvent is emitted by GameModel as a run progresses.
+type GameEvent struct {
+	Kind    GameEventKind
+	Session string
+	Level   int
+	Score   int
+	BigFish bool
+	Elapsed time.Duration
+}
+
+// RunProgress accumulates the events of one run (one session) so
+// achievements can be evaluated against it.
+type RunProgress struct {
+	Session   string        `json:"session"`
+	Level     int           `json:"level"`
+	Score     int           `json:"score"`
+	Paused    bool          `json:"paused"`
+	FoodEaten int           `json:"food_eaten"`
+	BigFish   int           `json:"big_fish"`
+	Elapsed   time.Duration `json:"elapsed"`
+}
+
+func (p *RunProgress) apply(event GameEvent) {
+	p.Level = max(p.Level, event.Level)
+	p.Score = max(p.Score, event.Score)
+	p.Elapsed = max(p.Elapsed, event.Elapsed)
+
+	switch event.Kind {
+	case EventPaused:
+		p.Paused = true
+	case EventFoodEaten:
+		p.FoodEaten++
+		if event.BigFish {
+			p.BigFish++
+		}
+	}
+}
+
+type Achievement struct {
+	ID          string
+	Title       string
+	Description string
+	unlocked    func(RunProgress) bool
+}
+
+type UnlockedAchievement struct {
+	Achievement
+	UnlockedAt time.Time
+}
+
+// Achievements lists every achievement in the order they are shown.
+var Achievements = []Achievement{
+	{
+		ID:          "first-bite",
+		Title:       "First Bite",
+		Description: "Eat your first piece of food",
+		unlocked:    func(p RunProgress) bool { return p.FoodEaten >= 1 },
+	},
+	{
+		ID:          "no-pause-level-3",
+		Title:       "Nerves of Steel",
+		Description: "Reach level 3 without pausing",
+		unlocked:    func(p RunProgress) bool { return p.Level >= 3 && !p.Paused },
+	},
+	{
+		ID:          "big-fish-5",
+		Title:       "Big Game Hunter",
+		Description: "Eat 5 big fish in one run",
+		unlocked:    func(p RunProgress) bool { return p.BigFish >= 5 },
+	},
+	{
+		ID:          "survive-10m",
+		Title:       "Marathon",
+		Description: "Survive 10 minutes",
+		unlocked:    func(p RunProgress) bool { return p.Elapsed >= 10*time.Minute },
+	},
+	{
+		ID:          "level-5",
+		Title:       "Super Snake",
+		Description: "Reach level 5",
+		unlocked:    func(p RunProgress) bool { return p.Level >= 5 },
+	},
+}
+
+type AchievementService interface {
+	Record(ctx context.Context, player string, event GameEvent) ([]Achievement, error)
+	GetUnlocked(ctx context.Context, player string) ([]UnlockedAchievement, error)
+	// Progress returns what has been recorded of session's run so far, so
+	// it can be saved along with the game.
+	Progress(session string) RunProgress
+	// ResumeProgress carries on a saved run from progress.
+	ResumeProgress(progress RunProgress)
+}
+
+func NewAchievementService(db *sql.DB) AchievementService {
+	return &AchievementServiceImpl{
+		db: db,
+	}
+}
+
+type AchievementServiceImpl struct {
+	db       *sql.DB
+	progress RunProgress
+	player   string
+	unlocked map[string]bool
+}
+
+// Record implements AchievementService. It folds event into the current run
+// and returns the achievements it unlocked for player.
+func (a *AchievementServiceImpl) Record(ctx context.Context, player string, event GameEvent) ([]Achievement, error) {
+	if a.player != player || a.unlocked == nil {
+		if err := a.loadUnlocked(ctx, player); err != nil {
+			return nil, err
+		}
+	}
+
+	if a.progress.Session != event.Session {
+		a.progress = RunProgress{Session: event.Session}
+	}
+
+	a.progress.apply(event)
+
+	var unlocked []Achievement
+	for _, achievement := range Achievements {
+		if a.unlocked[achievement.ID] || !achievement.unlocked(a.progress) {
+			continue
+		}
+
+		_, err := a.db.ExecContext(ctx,
+			`insert or ignore into achievements (player, achievement_id, session) values (?, ?, ?)`,
+			player, achievement.ID, event.Session,
+		)
+		if err != nil {
+			return unlocked, err
+		}
+
+		a.unlocked[achievement.ID] = true
+		unlocked = append(unlocked, achievement)
+	}
+
+	return unlocked, nil
+}
+
+// Progress implements AchievementService.
+func (a *AchievementServiceImpl) Progress(session string) RunProgress {
+	if a.progress.Session != session {
+		return RunProgress{Session: session}
+	}
+
+	return a.progress
+}
+
+// ResumeProgress implements AchievementService.
+func (a *AchievementServiceImpl) ResumeProgress(progress RunProgress) {
+	a.progress = progress
+}
+
+// GetUnlocked implements AchievementService.
+func (a *AchievementServiceImpl) GetUnlocked(ctx context.Context, player string) ([]UnlockedAchievement, error) {
+	unlockedAt := make(map[string]time.Time)
+
+	rows, err := a.db.QueryContext(ctx, `select achievement_id, unlocked_at from achievements where player = ?`, player)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			id string
+			at time.Time
+		)
+
+		if err := rows.Scan(&id, &at); err != nil {
+			return nil, err
+		}
+
+		unlockedAt[id] = at
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	unlocked := make([]UnlockedAchievement, 0, len(unlockedAt))
+	for _, achievement := range Achievements {
+		if at, ok := unlockedAt[achievement.ID]; ok {
+			unlocked = append(unlocked, UnlockedAchievement{Achievement: achievement, UnlockedAt: at})
+		}
+	}
+
+	return unlocked, nil
+}
+
+func (a *AchievementServiceImpl) loadUnlocked(ctx context.Context, player string) error {
+	unlocked, err := a.GetUnlocked(ctx, player)
+	if err != nil {
+		return err
+	}
+
+	a.player = player
+	a.unlocked = make(map[string]bool, len(unlocked))
+	for _, achievement := range unlocked {
+		a.unlocked[achievement.ID] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package internal_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/the-Jinxist/golang_snake_game/internal"
+)
+
+func ids(achievements []internal.Achievement) []string {
+	ids := make([]string, 0, len(achievements))
+	for _, achievement := range achievements {
+		ids = append(ids, achievement.ID)
+	}
+
+	return ids
+}
+
+// record plays events through achievements as player and returns every
+// achievement they unlocked.
+func record(t *testing.T, achievements internal.AchievementService, player string, events ...internal.GameEvent) []string {
+	t.Helper()
+
+	var unlocked []string
+	for _, event := range events {
+		got, err := achievements.Record(context.Background(), player, event)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		unlocked = append(unlocked, ids(got)...)
+	}
+
+	return unlocked
+}
+
+func TestAchievementsUnlock(t *testing.T) {
+	food := internal.GameEvent{Kind: internal.EventFoodEaten, Session: "run"}
+	bigFish := internal.GameEvent{Kind: internal.EventFoodEaten, Session: "run", BigFish: true}
+
+	tests := []struct {
+		name   string
+		events []internal.GameEvent
+		want   []string
+	}{
+		{name: "nothing eaten", events: []internal.GameEvent{{Kind: internal.EventLevelStarted, Session: "run", Level: 1}}},
+		{name: "first bite", events: []internal.GameEvent{food}, want: []string{"first-bite"}},
+		{name: "four big fish", events: slices.Repeat([]internal.GameEvent{bigFish}, 4), want: []string{"first-bite"}},
+		{name: "five big fish", events: slices.Repeat([]internal.GameEvent{bigFish}, 5), want: []string{"first-bite", "big-fish-5"}},
+		{
+			name: "five big fish over two runs",
+			events: append(
+				slices.Repeat([]internal.GameEvent{bigFish}, 3),
+				slices.Repeat([]internal.GameEvent{{Kind: internal.EventFoodEaten, Session: "next", BigFish: true}}, 3)...,
+			),
+			want: []string{"first-bite"},
+		},
+		{name: "level 3 without pausing", events: []internal.GameEvent{{Kind: internal.EventLevelStarted, Session: "run", Level: 3}}, want: []string{"no-pause-level-3"}},
+		{
+			name: "level 3 after pausing",
+			events: []internal.GameEvent{
+				{Kind: internal.EventPaused, Session: "run", Level: 1},
+				{Kind: internal.EventLevelStarted, Session: "run", Level: 3},
+			},
+		},
+		{name: "level 5", events: []internal.GameEvent{{Kind: internal.EventLevelStarted, Session: "run", Level: 5}}, want: []string{"no-pause-level-3", "level-5"}},
+		{name: "ten minutes", events: []internal.GameEvent{{Kind: internal.EventTick, Session: "run", Elapsed: 10 * time.Minute}}, want: []string{"survive-10m"}},
+		{name: "nine minutes", events: []internal.GameEvent{{Kind: internal.EventTick, Session: "run", Elapsed: 9 * time.Minute}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			achievements := internal.NewAchievementService(openDB(t))
+
+			if got := record(t, achievements, "alice", test.events...); !slices.Equal(got, test.want) {
+				t.Fatalf("unlocked %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestAchievementsUnlockOncePerPlayer(t *testing.T) {
+	db := openDB(t)
+	achievements := internal.NewAchievementService(db)
+	food := internal.GameEvent{Kind: internal.EventFoodEaten, Session: "alice's run"}
+
+	if got := record(t, achievements, "alice", food, food); !slices.Equal(got, []string{"first-bite"}) {
+		t.Fatalf("alice unlocked %v, want first-bite once", got)
+	}
+
+	// A fresh service, as on the next launch, still knows what alice has.
+	achievements = internal.NewAchievementService(db)
+	if got := record(t, achievements, "alice", internal.GameEvent{Kind: internal.EventFoodEaten, Session: "later"}); len(got) != 0 {
+		t.Fatalf("alice unlocked %v again", got)
+	}
+
+	if got := record(t, achievements, "bob", internal.GameEvent{Kind: internal.EventFoodEaten, Session: "bob's run"}); !slices.Equal(got, []string{"first-bite"}) {
+		t.Fatalf("bob unlocked %v, want bob's own first-bite", got)
+	}
+
+	unlocked, err := achievements.GetUnlocked(context.Background(), "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(unlocked) != 1 || unlocked[0].ID != "first-bite" || unlocked[0].UnlockedAt.IsZero() {
+		t.Fatalf("alice's achievements = %+v, want first-bite with when it was unlocked", unlocked)
+	}
+}
+
+func TestAchievementsResumeProgress(t *testing.T) {
+	achievements := internal.NewAchievementService(openDB(t))
+	bigFish := internal.GameEvent{Kind: internal.EventFoodEaten, Session: "saved", BigFish: true}
+
+	record(t, achievements, "alice", slices.Repeat([]internal.GameEvent{bigFish}, 3)...)
+	progress := achievements.Progress("saved")
+
+	// Playing something else in between, then continuing the saved game.
+	record(t, achievements, "alice", internal.GameEvent{Kind: internal.EventFoodEaten, Session: "other"})
+	achievements.ResumeProgress(progress)
+
+	if got := record(t, achievements, "alice", bigFish, bigFish); !slices.Equal(got, []string{"big-fish-5"}) {
+		t.Fatalf("unlocked %v after continuing, want big-fish-5", got)
+	}
+}
@@ -2,39 +2,207 @@ package internal
 
 import (
 	"database/sql"
+	"embed"
 	"fmt"
-	"log"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	_ "modernc.org/sqlite"
 )
 
-var db *sql.DB
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
 
-func CreateDB() *sql.DB {
-	db, err := sql.Open("sqlite", "./my.db")
+type Migration struct {
+	Version int
+	Name    string
+	SQL     string
+}
+
+type MigrationStatus struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// CreateDB opens the database at path and brings its schema up to date.
+// Failures wrap ErrStoreUnavailable.
+func CreateDB(path string) (*sql.DB, error) {
+	db, err := OpenDB(path)
 	if err != nil {
-		fmt.Println(err)
-		return nil
+		return nil, err
 	}
 
-	err = execScoreTableCreation(db)
+	err = Migrate(db)
 	if err != nil {
-		fmt.Println("execScoreTableCreation: ", err)
-		return nil
+		db.Close()
+		return nil, fmt.Errorf("%w: migrate: %w", ErrStoreUnavailable, err)
 	}
 
-	return db
+	return db, nil
 }
 
-func execScoreTableCreation(db *sql.DB) error {
-	sqlStmt := `
-	create table if not exists scores (id integer not null primary key autoincrement, "user" text, session text unique, value integer, created_at datetime default current_timestamp);
-	`
-	_, err := db.Exec(sqlStmt)
+// OpenDB opens the database at path as it is, without migrating it, so
+// maintenance commands can look at the schema before changing it. Failures
+// wrap ErrStoreUnavailable.
+func OpenDB(path string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrStoreUnavailable, err)
+	}
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("%w: %w", ErrStoreUnavailable, err)
+	}
+
+	return db, nil
+}
+
+// Migrate applies every embedded migration newer than the database's
+// recorded schema version, each in its own transaction.
+func Migrate(db *sql.DB) error {
+	err := execSchemaVersionTableCreation(db)
+	if err != nil {
+		return err
+	}
+
+	statuses, err := MigrationStatuses(db)
 	if err != nil {
-		log.Printf("%q: %s\n", err, sqlStmt)
 		return err
 	}
 
+	for _, status := range statuses {
+		if status.Applied {
+			continue
+		}
+
+		err = applyMigration(db, status.Migration)
+		if err != nil {
+			return fmt.Errorf("migration %04d_%s: %w", status.Version, status.Name, err)
+		}
+	}
+
 	return nil
 }
+
+// MigrationStatuses lists the embedded migrations and whether each has been
+// applied to db.
+func MigrationStatuses(db *sql.DB) ([]MigrationStatus, error) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	// A database that was never migrated has nothing applied yet.
+	err = execSchemaVersionTableCreation(db)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int]time.Time)
+	rows, err := db.Query(`select version, applied_at from schema_version`)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			version   int
+			appliedAt time.Time
+		)
+
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+
+		applied[version] = appliedAt
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(migrations))
+	for _, migration := range migrations {
+		appliedAt, ok := applied[migration.Version]
+		statuses = append(statuses, MigrationStatus{
+			Migration: migration,
+			Applied:   ok,
+			AppliedAt: appliedAt,
+		})
+	}
+
+	return statuses, nil
+}
+
+func loadMigrations() ([]Migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := make([]Migration, 0, len(entries))
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), ".sql")
+		versionPart, namePart, ok := strings.Cut(name, "_")
+		if !ok {
+			return nil, fmt.Errorf("migration %q is not named <version>_<name>.sql", entry.Name())
+		}
+
+		version, err := strconv.Atoi(versionPart)
+		if err != nil {
+			return nil, fmt.Errorf("migration %q has an invalid version: %w", entry.Name(), err)
+		}
+
+		contents, err := migrationFiles.ReadFile("migrations/" + entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migrations = append(migrations, Migration{
+			Version: version,
+			Name:    namePart,
+			SQL:     string(contents),
+		})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+func applyMigration(db *sql.DB, migration Migration) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	_, err = tx.Exec(migration.SQL)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`insert into schema_version (version, name) values (?, ?)`, migration.Version, migration.Name)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func execSchemaVersionTableCreation(db *sql.DB) error {
+	sqlStmt := `
+	create table if not exists schema_version (version integer not null primary key, name text not null, applied_at datetime default current_timestamp);
+	`
+	_, err := db.Exec(sqlStmt)
+	return err
+}
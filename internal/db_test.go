@@ -0,0 +1,97 @@
+package internal
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestMigrate(t *testing.T) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		// applied is how many migrations the database has before Migrate.
+		applied int
+	}{
+		{name: "fresh database", applied: 0},
+		{name: "partly migrated", applied: 3},
+		{name: "up to date", applied: len(migrations)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, err := OpenDB(filepath.Join(t.TempDir(), "scores.db"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer db.Close()
+
+			if err := execSchemaVersionTableCreation(db); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, migration := range migrations[:test.applied] {
+				if err := applyMigration(db, migration); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			statuses, err := MigrationStatuses(db)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for index, status := range statuses {
+				if want := index < test.applied; status.Applied != want {
+					t.Fatalf("before Migrate, %04d applied = %t, want %t", status.Version, status.Applied, want)
+				}
+			}
+
+			if err := Migrate(db); err != nil {
+				t.Fatalf("Migrate() = %v", err)
+			}
+
+			statuses, err = MigrationStatuses(db)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, status := range statuses {
+				if !status.Applied {
+					t.Fatalf("after Migrate, %04d_%s is still pending", status.Version, status.Name)
+				}
+			}
+
+			// Running again is a no-op rather than re-applying anything.
+			if err := Migrate(db); err != nil {
+				t.Fatalf("second Migrate() = %v", err)
+			}
+
+			var count int
+			if err := db.QueryRow(`select count(*) from schema_version`).Scan(&count); err != nil {
+				t.Fatal(err)
+			}
+
+			if count != len(migrations) {
+				t.Fatalf("schema_version has %d rows, want %d", count, len(migrations))
+			}
+		})
+	}
+}
+
+func TestMigrationsAreNumberedInOrder(t *testing.T) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for index, migration := range migrations {
+		if migration.Version != index+1 {
+			t.Fatalf("migration %d is numbered %04d, want %04d", index, migration.Version, index+1)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package internal
+
+import (
+	"context"
+	"strings"
+)
+
+// DeathHotspot is a board cell and how many runs ended there.
+type DeathHotspot struct {
+	Level  int
+	Cause  DeathCause
+	X      int
+	Y      int
+	Deaths int
+}
+
+// DeathQuery narrows down ScoreService.GetDeathHotspots. Zero values mean
+// "any".
+type DeathQuery struct {
+	Level *int
+	Cause DeathCause
+	Limit int
+}
+
+// SetCurrentDeath implements ScoreService. It records how and where the
+// current session's run ended, creating the score row for runs that died
+// before scoring so every death is counted.
+func (s *ScoreServiceImpol) SetCurrentDeath(ctx context.Context, cause DeathCause, x, y int) error {
+	session, err := s.Session.GetCurrentSession()
+	if err != nil {
+		return storeError("set current death", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+	insert into scores ("user", session, value, level, mode, board, death_cause, death_x, death_y)
+	values (?, ?, 0, ?, ?, ?, ?, ?, ?)
+	on conflict(session) do update set
+		death_cause = excluded.death_cause,
+		death_x = excluded.death_x,
+		death_y = excluded.death_y`,
+		s.CurrentUser, session, s.run.Level, s.run.Mode, s.run.Board, cause, x, y,
+	)
+	return storeError("set current death", err)
+}
+
+// GetDeathHotspots implements ScoreService. Cells are ordered by the number
+// of runs that ended on them, most first.
+func (s *ScoreServiceImpol) GetDeathHotspots(ctx context.Context, query DeathQuery) ([]DeathHotspot, error) {
+	hotspots := make([]DeathHotspot, 0)
+
+	conditions := []string{"death_cause != ''"}
+	args := make([]any, 0, 3)
+
+	if query.Level != nil {
+		conditions = append(conditions, "level = ?")
+		args = append(args, *query.Level)
+	}
+
+	if query.Cause != DeathNone {
+		conditions = append(conditions, "death_cause = ?")
+		args = append(args, query.Cause)
+	}
+
+	stmt := `select level, death_cause, death_x, death_y, count(*) as deaths from scores
+	where ` + strings.Join(conditions, " and ") + `
+	group by level, death_cause, death_x, death_y
+	order by deaths desc, level asc, death_x asc, death_y asc`
+
+	if query.Limit > 0 {
+		stmt += ` limit ?`
+		args = append(args, query.Limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return hotspots, storeError("get death hotspots", err)
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var hotspot DeathHotspot
+		err = rows.Scan(&hotspot.Level, &hotspot.Cause, &hotspot.X, &hotspot.Y, &hotspot.Deaths)
+		if err != nil {
+			return hotspots, storeError("get death hotspots", err)
+		}
+
+		hotspots = append(hotspots, hotspot)
+	}
+
+	return hotspots, storeError("get death hotspots", rows.Err())
+}
@@ -0,0 +1,38 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrStoreUnavailable is returned when the scores database cannot be opened
+// or migrated, so nothing can be read from or written to it.
+var ErrStoreUnavailable = errors.New("scores database is unavailable")
+
+// ErrSessionTaken is returned when a score is submitted for a session that
+// another player's score already holds.
+var ErrSessionTaken = errors.New("session belongs to another player")
+
+// StoreError reports a failed read or write against the score store. Op names
+// what was being done, e.g. "get high score".
+type StoreError struct {
+	Op  string
+	Err error
+}
+
+func (e *StoreError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Op, e.Err)
+}
+
+func (e *StoreError) Unwrap() error {
+	return e.Err
+}
+
+// storeError wraps err in a StoreError for op, leaving nil as nil.
+func storeError(op string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &StoreError{Op: op, Err: err}
+}
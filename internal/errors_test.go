@@ -0,0 +1,78 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/the-Jinxist/golang_snake_game/internal"
+)
+
+// TestStoreErrors checks that reads and writes against a database that has
+// gone away say what they were doing.
+func TestStoreErrors(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		op  string
+		run func(scores internal.ScoreService) error
+	}{
+		{op: "get high score", run: func(scores internal.ScoreService) error {
+			_, err := scores.GetHighScore(ctx)
+			return err
+		}},
+		{op: "query scores", run: func(scores internal.ScoreService) error {
+			_, err := scores.QueryScores(ctx, internal.ScoreQuery{})
+			return err
+		}},
+		{op: "count scores", run: func(scores internal.ScoreService) error {
+			_, err := scores.CountScores(ctx, internal.ScoreQuery{})
+			return err
+		}},
+		{op: "get replay", run: func(scores internal.ScoreService) error {
+			_, err := scores.GetReplay(ctx, "a")
+			return err
+		}},
+		{op: "get death hotspots", run: func(scores internal.ScoreService) error {
+			_, err := scores.GetDeathHotspots(ctx, internal.DeathQuery{})
+			return err
+		}},
+		{op: "submit score", run: func(scores internal.ScoreService) error {
+			return scores.SubmitScore(ctx, internal.Score{User: "bob", Session: "a", Value: 10}, nil)
+		}},
+		{op: "set current score", run: func(scores internal.ScoreService) error {
+			return scores.SetCurrentScore(ctx, 10, 0)
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.op, func(t *testing.T) {
+			db := openDB(t)
+
+			sessions, err := internal.NewSQLiteSessionManager("alice", db)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			scores := internal.NewScoreService("alice", sessions, db)
+			db.Close()
+
+			var storeErr *internal.StoreError
+			if err := test.run(scores); !errors.As(err, &storeErr) || storeErr.Op != test.op {
+				t.Fatalf("error = %v, want a StoreError for %q", err, test.op)
+			}
+		})
+	}
+}
+
+func TestStoreErrorUnwraps(t *testing.T) {
+	err := error(&internal.StoreError{Op: "get scores", Err: internal.ErrStoreUnavailable})
+
+	if !errors.Is(err, internal.ErrStoreUnavailable) {
+		t.Fatalf("errors.Is(%v, ErrStoreUnavailable) = false", err)
+	}
+
+	if want := "get scores: " + internal.ErrStoreUnavailable.Error(); err.Error() != want {
+		t.Fatalf("Error() = %q, want %q", err, want)
+	}
+}
@@ -1,36 +1,356 @@
 package internal
 
 import (
-	"log"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"os"
-
-	_ "github.com/mattn/go-sqlite3"
 )
 
-var (
-	sessionManager SessionManager
-	scoreService   ScoreService
+// StorageBackend names where scores and sessions are kept on disk.
+type StorageBackend string
+
+const (
+	// StorageSQLite keeps everything in a SQLite database. It is the only
+	// backend with player profiles, stats and achievements.
+	StorageSQLite StorageBackend = "sqlite"
+	// StorageJSON keeps scores and sessions in a single JSON file.
+	StorageJSON StorageBackend = "json"
 )
 
-func GetSessionManager() SessionManager {
-	return sessionManager
+// ParseStorageBackend validates a --storage value; empty means SQLite.
+func ParseStorageBackend(value string) (StorageBackend, error) {
+	switch backend := StorageBackend(value); backend {
+	case "":
+		return StorageSQLite, nil
+	case StorageSQLite, StorageJSON:
+		return backend, nil
+	default:
+		return "", fmt.Errorf("unknown storage %q, use sqlite or json", value)
+	}
+}
+
+// Options select where the game keeps its data.
+type Options struct {
+	// Storage picks the backend; empty means SQLite.
+	Storage StorageBackend
+	// DBPath overrides the store location; see ResolveDBPath and
+	// ResolveJSONStorePath.
+	DBPath string
+	// Player is the profile to play as; empty means the last active one.
+	Player string
+	// LeaderboardURL points scores at a shared leaderboard server. The
+	// remote store keeps a copy in the local backend for offline play.
+	LeaderboardURL string
+	// Guest keeps everything in memory so nothing is written to disk.
+	Guest bool
+	// FallbackToMemory plays as a guest instead of failing when the
+	// database cannot be opened; the reason is kept in App.StorageErr.
+	FallbackToMemory bool
+	// Maintenance opens the SQLite database without migrating it and
+	// without any services on top, for the db commands. Only App.DB is set.
+	Maintenance bool
+}
+
+const guestPlayerName = "guest"
+
+// App holds the services one game instance runs against. Each App is
+// independent, so several games can run in the same process. Players, Stats
+// and Achievements are nil when the backend cannot keep them.
+type App struct {
+	// DB is the SQLite database, or nil for other backends.
+	DB           *sql.DB
+	Guest        bool
+	Sessions     SessionManager
+	Scores       ScoreService
+	Players      PlayerService
+	Stats        StatsStore
+	Achievements AchievementService
+
+	// StorePath is where the database or JSON store lives, made absolute.
+	// It is empty for guests, who keep nothing on disk.
+	StorePath string
+
+	// StorageErr is why the app fell back to in-memory services, if it did.
+	StorageErr error
+
+	// Settings are the player's preferences, loaded at startup.
+	Settings Settings
+	// ThemeOverride is a theme picked for this run only, by the --theme
+	// flag. It wins over Settings.Theme and is never saved.
+	ThemeOverride string
+	// SettingsErr is why the settings file could not be read, if it could
+	// not; the defaults are used instead.
+	SettingsErr error
+}
+
+// NewApp opens the store described by options, builds the services on top of
+// it and loads the player's settings. Guest apps use in-memory services and
+// never write to disk.
+func NewApp(options Options) (*App, error) {
+	app, err := newApp(options)
+	if err != nil {
+		return nil, err
+	}
+
+	app.Settings, app.SettingsErr = LoadSettings()
+	return app, nil
+}
+
+func newApp(options Options) (*App, error) {
+	if options.Guest {
+		return newGuestApp(options)
+	}
+
+	var (
+		app    *App
+		player string
+		err    error
+	)
+
+	switch options.Storage {
+	case "", StorageSQLite:
+		if options.Maintenance {
+			return newMaintenanceApp(options)
+		}
+
+		app, player, err = newSQLiteApp(options)
+	case StorageJSON:
+		app, player, err = newJSONApp(options)
+	default:
+		_, err = ParseStorageBackend(string(options.Storage))
+		return nil, err
+	}
+
+	if err != nil {
+		if options.FallbackToMemory && errors.Is(err, ErrStoreUnavailable) {
+			return newFallbackApp(options, err)
+		}
+
+		return nil, err
+	}
+
+	if options.LeaderboardURL != "" {
+		app.Scores, err = NewRemoteScoreService(options.LeaderboardURL, app.Scores)
+		if err != nil {
+			app.Close()
+			return nil, fmt.Errorf("leaderboard server cannot be used: %w", err)
+		}
+	}
+
+	if err := app.SetActivePlayer(player); err != nil {
+		app.Close()
+		return nil, fmt.Errorf("player %q cannot be selected: %w", player, err)
+	}
+
+	return app, nil
 }
 
-func GetScoreService() ScoreService {
-	return scoreService
+// newSQLiteApp opens the SQLite database and returns the app along with the
+// player it should start as.
+func newSQLiteApp(options Options) (*App, string, error) {
+	path, err := ResolveDBPath(options.DBPath)
+	if err != nil {
+		return nil, "", fmt.Errorf("database location cannot be resolved: %w", err)
+	}
+
+	db, err := CreateDB(path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	player := options.Player
+	if player == "" {
+		player, err = defaultPlayerName(db)
+		if err != nil {
+			db.Close()
+			return nil, "", err
+		}
+	}
+
+	sessions, err := NewSQLiteSessionManager(player, db)
+	if err != nil {
+		db.Close()
+		return nil, "", fmt.Errorf("session manager cannot be initialized: %w", err)
+	}
+
+	return &App{
+		DB:           db,
+		StorePath:    absPath(path),
+		Sessions:     sessions,
+		Scores:       NewScoreService(player, sessions, db),
+		Players:      NewPlayerService(db),
+		Stats:        NewStatsStore(db),
+		Achievements: NewAchievementService(db),
+	}, player, nil
 }
 
-func IntializeConfigs() {
-	db := CreateDB()
-	if db == nil {
-		log.Fatalf("sqlite db cannot be initialized")
+// newMaintenanceApp opens the SQLite database as it is on disk.
+func newMaintenanceApp(options Options) (*App, error) {
+	path, err := ResolveDBPath(options.DBPath)
+	if err != nil {
+		return nil, fmt.Errorf("database location cannot be resolved: %w", err)
+	}
+
+	db, err := OpenDB(path)
+	if err != nil {
+		return nil, err
 	}
 
+	return &App{DB: db, StorePath: absPath(path)}, nil
+}
+
+// newJSONApp loads the JSON store. It has no profiles, so the player is the
+// one asked for or the machine's host name.
+func newJSONApp(options Options) (*App, string, error) {
+	path, err := ResolveJSONStorePath(options.DBPath)
+	if err != nil {
+		return nil, "", fmt.Errorf("store location cannot be resolved: %w", err)
+	}
+
+	player := options.Player
+	if player == "" {
+		player, err = hostPlayerName()
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	store, err := OpenJSONStore(path, player)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &App{
+		StorePath: absPath(path),
+		Sessions:  store.Sessions(),
+		Scores:    store.Scores(),
+	}, player, nil
+}
+
+// newGuestApp sets up in-memory services only.
+func newGuestApp(options Options) (*App, error) {
+	if options.LeaderboardURL != "" {
+		return nil, errors.New("guest play cannot submit to a leaderboard server")
+	}
+
+	player := options.Player
+	if player == "" {
+		player = guestPlayerName
+	}
+
+	sessions := NewSessionManager()
+	app := &App{
+		Guest:    true,
+		Sessions: sessions,
+		Scores:   NewInMemoryScoreService(player, sessions),
+	}
+
+	return app, app.SetActivePlayer(player)
+}
+
+// newFallbackApp sets up a guest app for when the database at the requested
+// location could not be opened, recording why.
+func newFallbackApp(options Options, storageErr error) (*App, error) {
+	options.Guest = true
+	options.LeaderboardURL = ""
+
+	app, err := newGuestApp(options)
+	if err != nil {
+		return nil, err
+	}
+
+	app.StorageErr = storageErr
+	return app, nil
+}
+
+// UseMemory switches a running app to in-memory services after its store
+// failed with storageErr, keeping the current player, so the rest of the
+// program plays on without saving.
+func (a *App) UseMemory(storageErr error) {
+	player := ""
+	if a.Scores != nil {
+		player = a.Scores.GetCurrentUser()
+	}
+
+	sessions := NewSessionManager()
+	sessions.SetUser(player)
+
+	a.Guest = true
+	a.Sessions = sessions
+	a.Scores = NewInMemoryScoreService(player, sessions)
+	a.Stats = nil
+	a.Achievements = nil
+	a.Players = nil
+	a.StorageErr = storageErr
+}
+
+// SetActivePlayer switches the profile that new sessions and scores are
+// recorded against.
+func (a *App) SetActivePlayer(name string) error {
+	if a.Players != nil {
+		player, err := a.Players.UsePlayer(context.Background(), name)
+		if err != nil {
+			return err
+		}
+
+		name = player.Name
+	}
+
+	a.Scores.SetCurrentUser(name)
+	a.Sessions.SetUser(name)
+	return nil
+}
+
+// SaveSettings persists settings and applies them to games started from now
+// on.
+func (a *App) SaveSettings(settings Settings) error {
+	if a.Guest {
+		return errors.New("settings are not saved in guest play")
+	}
+
+	if err := SaveSettings(settings); err != nil {
+		return err
+	}
+
+	// Picking a theme in the settings replaces the one from --theme.
+	if settings.Theme != a.Settings.Theme {
+		a.ThemeOverride = ""
+	}
+
+	a.Settings = settings
+	a.SettingsErr = nil
+	return nil
+}
+
+// Close releases the database, if the app has one.
+func (a *App) Close() error {
+	if a.DB == nil {
+		return nil
+	}
+
+	return a.DB.Close()
+}
+
+// defaultPlayerName picks the most recently active profile, falling back to
+// the machine's host name on a fresh database.
+func defaultPlayerName(db *sql.DB) (string, error) {
+	player, err := NewPlayerService(db).GetLastActivePlayer(context.Background())
+	if err == nil {
+		return player.Name, nil
+	}
+
+	return hostPlayerName()
+}
+
+// hostPlayerName names the player after the machine, as the game always
+// did before it had profiles.
+func hostPlayerName() (string, error) {
 	user, err := os.Hostname()
 	if err != nil {
-		log.Fatalf("system host name cannot be retrieved")
+		return "", fmt.Errorf("system host name cannot be retrieved: %w", err)
 	}
 
-	sessionManager = NewSessionManager()
-	scoreService = NewScoreService(user, sessionManager, db)
+	return user, nil
 }
@@ -0,0 +1,158 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/the-Jinxist/golang_snake_game/internal"
+)
+
+func TestNewApp(t *testing.T) {
+	tests := []struct {
+		name    string
+		options func(dir string) internal.Options
+		// profiles is whether the app has players, stats and achievements.
+		profiles    bool
+		wantGuest   bool
+		wantStorage bool
+		wantErr     bool
+	}{
+		{
+			name:     "sqlite",
+			options:  func(dir string) internal.Options { return internal.Options{DBPath: filepath.Join(dir, "scores.db")} },
+			profiles: true,
+		},
+		{
+			name: "json",
+			options: func(dir string) internal.Options {
+				return internal.Options{Storage: internal.StorageJSON, DBPath: filepath.Join(dir, "scores.json")}
+			},
+		},
+		{
+			name:    "unknown storage",
+			options: func(dir string) internal.Options { return internal.Options{Storage: "csv"} },
+			wantErr: true,
+		},
+		{
+			name: "database that cannot be opened",
+			options: func(dir string) internal.Options {
+				return internal.Options{DBPath: filepath.Join(dir, "missing", "scores.db")}
+			},
+			wantErr: true,
+		},
+		{
+			name: "database that cannot be opened, falling back",
+			options: func(dir string) internal.Options {
+				return internal.Options{DBPath: filepath.Join(dir, "missing", "scores.db"), FallbackToMemory: true}
+			},
+			wantGuest:   true,
+			wantStorage: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+			options := test.options(t.TempDir())
+			options.Player = "alice"
+
+			app, err := internal.NewApp(options)
+			if test.wantErr {
+				if err == nil {
+					app.Close()
+					t.Fatal("NewApp() = nil, want an error")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer app.Close()
+
+			if app.Guest != test.wantGuest {
+				t.Fatalf("app.Guest = %t, want %t", app.Guest, test.wantGuest)
+			}
+
+			if storageErr := errors.Is(app.StorageErr, internal.ErrStoreUnavailable); storageErr != test.wantStorage {
+				t.Fatalf("app.StorageErr = %v, want store unavailable: %t", app.StorageErr, test.wantStorage)
+			}
+
+			if profiles := app.Players != nil && app.Stats != nil && app.Achievements != nil; profiles != test.profiles {
+				t.Fatalf("app has profiles: %t, want %t", profiles, test.profiles)
+			}
+
+			if got := app.Scores.GetCurrentUser(); got != "alice" {
+				t.Fatalf("player = %q, want alice", got)
+			}
+		})
+	}
+}
+
+// TestAppsAreIndependent runs two games in one process, each against its own
+// database, as an SSH server would.
+func TestAppsAreIndependent(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	ctx := context.Background()
+
+	var apps []*internal.App
+	for _, player := range []string{"alice", "bob"} {
+		app, err := internal.NewApp(internal.Options{DBPath: filepath.Join(t.TempDir(), "scores.db"), Player: player})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		t.Cleanup(func() { app.Close() })
+		apps = append(apps, app)
+	}
+
+	if err := apps[0].Scores.SetCurrentScore(ctx, 40, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	for index, want := range []int{1, 0} {
+		count, err := apps[index].Scores.CountScores(ctx, internal.ScoreQuery{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if count != want {
+			t.Fatalf("app %d sees %d scores, want %d", index, count, want)
+		}
+	}
+
+	if got := apps[1].Scores.GetCurrentUser(); got != "bob" {
+		t.Fatalf("bob's app plays as %q", got)
+	}
+}
+
+func TestAppUseMemory(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	app, err := internal.NewApp(internal.Options{DBPath: filepath.Join(t.TempDir(), "scores.db"), Player: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer app.Close()
+
+	failure := errors.New("disk full")
+	app.UseMemory(failure)
+
+	if !app.Guest || app.Players != nil || app.Stats != nil || app.Achievements != nil {
+		t.Fatal("app still uses the database after switching to memory")
+	}
+
+	if !errors.Is(app.StorageErr, failure) {
+		t.Fatalf("app.StorageErr = %v, want %v", app.StorageErr, failure)
+	}
+
+	if got := app.Scores.GetCurrentUser(); got != "alice" {
+		t.Fatalf("player after switching = %q, want alice", got)
+	}
+}
@@ -0,0 +1,126 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Backup writes a consistent copy of db to path with VACUUM INTO, which is
+// safe to run while the game is using the database. It refuses to overwrite
+// an existing file.
+func Backup(ctx context.Context, db *sql.DB, path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists", path)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	_, err := db.ExecContext(ctx, `vacuum into ?`, path)
+	return err
+}
+
+// PruneOptions select which scores Prune deletes.
+type PruneOptions struct {
+	// KeepTop scores, counted from the highest, are never deleted.
+	KeepTop int
+	// OlderThan is the cutoff: only scores recorded before it are deleted.
+	OlderThan time.Time
+	// DryRun counts what would be deleted without deleting it.
+	DryRun bool
+}
+
+// PruneResult counts the rows Prune deleted, or would delete on a dry run.
+type PruneResult struct {
+	Scores   int64
+	Sessions int64
+	Replays  int64
+}
+
+// Prune deletes scores recorded before options.OlderThan that are not among
+// the options.KeepTop highest, along with their replays. Finished and
+// abandoned sessions older than the cutoff that no longer have a score go
+// too. Scores of active and suspended sessions are kept so saved games can
+// still be continued. Run Vacuum afterwards to reclaim the space.
+func Prune(ctx context.Context, db *sql.DB, options PruneOptions) (PruneResult, error) {
+	var result PruneResult
+
+	cutoff := options.OlderThan.UTC().Format(sqliteTimeLayout)
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return result, err
+	}
+
+	defer tx.Rollback()
+
+	scores, err := tx.ExecContext(ctx, `
+	delete from scores
+	where created_at < ?
+		and id not in (select id from scores order by value desc, created_at asc limit ?)
+		and session not in (select id from sessions where state in (?, ?))`,
+		cutoff, options.KeepTop, SessionActive, SessionSuspended,
+	)
+	if err != nil {
+		return result, err
+	}
+
+	replays, err := tx.ExecContext(ctx, `delete from replays where session not in (select session from scores)`)
+	if err != nil {
+		return result, err
+	}
+
+	sessions, err := tx.ExecContext(ctx, `
+	delete from sessions
+	where started_at < ?
+		and state in (?, ?)
+		and id not in (select session from scores)`,
+		cutoff, SessionFinished, SessionAbandoned,
+	)
+	if err != nil {
+		return result, err
+	}
+
+	result.Scores, _ = scores.RowsAffected()
+	result.Replays, _ = replays.RowsAffected()
+	result.Sessions, _ = sessions.RowsAffected()
+
+	if options.DryRun {
+		return result, nil
+	}
+
+	return result, tx.Commit()
+}
+
+// resetTables are cleared by Reset. Player profiles are kept.
+var resetTables = []string{"scores", "replays", "sessions", "game_stats", "achievements"}
+
+// Reset deletes every recorded score, session, replay, stat and achievement.
+// Player profiles and the schema are left in place. Run Vacuum afterwards to
+// reclaim the space.
+func Reset(ctx context.Context, db *sql.DB) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	for _, table := range resetTables {
+		if _, err := tx.ExecContext(ctx, `delete from `+table); err != nil {
+			return fmt.Errorf("clear %s: %w", table, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Vacuum rebuilds the database file to reclaim the space deleted rows took
+// up. Nothing is lost if it fails; the file just stays its old size.
+func Vacuum(ctx context.Context, db *sql.DB) error {
+	_, err := db.ExecContext(ctx, `vacuum`)
+	return err
+}
@@ -0,0 +1,150 @@
+package internal_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/the-Jinxist/golang_snake_game/internal"
+)
+
+func TestPrune(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	old := now.AddDate(0, -6, 0)
+
+	tests := []struct {
+		name    string
+		options internal.PruneOptions
+		want    internal.PruneResult
+		// kept are the sessions whose scores are left afterwards.
+		kept []string
+	}{
+		{
+			name:    "old scores below the top",
+			options: internal.PruneOptions{OlderThan: now.AddDate(0, -1, 0), KeepTop: 1},
+			want:    internal.PruneResult{Scores: 2, Replays: 1},
+			kept:    []string{"old-best", "new"},
+		},
+		{
+			name:    "nothing kept at the top",
+			options: internal.PruneOptions{OlderThan: now.AddDate(0, -1, 0)},
+			want:    internal.PruneResult{Scores: 3, Replays: 1},
+			kept:    []string{"new"},
+		},
+		{
+			name:    "cutoff before every score",
+			options: internal.PruneOptions{OlderThan: old.AddDate(0, -1, 0)},
+			kept:    []string{"old-best", "old-1", "old-2", "new"},
+		},
+		{
+			name:    "dry run",
+			options: internal.PruneOptions{OlderThan: now.AddDate(0, -1, 0), KeepTop: 1, DryRun: true},
+			want:    internal.PruneResult{Scores: 2, Replays: 1},
+			kept:    []string{"old-best", "old-1", "old-2", "new"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			db := openDB(t)
+
+			sessions, err := internal.NewSQLiteSessionManager("alice", db)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			scores := internal.NewScoreService("alice", sessions, db)
+			for _, score := range []internal.Score{
+				{Session: "old-best", Value: 90, CreatedAt: old},
+				{Session: "old-1", Value: 30, CreatedAt: old},
+				{Session: "old-2", Value: 20, CreatedAt: old},
+				{Session: "new", Value: 10, CreatedAt: now},
+			} {
+				score.User = "alice"
+
+				var replay []internal.LevelReplay
+				if score.Session == "old-1" {
+					replay = []internal.LevelReplay{{Seed: 1, Ticks: 3}}
+				}
+
+				if err := scores.SubmitScore(ctx, score, replay); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			result, err := internal.Prune(ctx, db, test.options)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if result != test.want {
+				t.Fatalf("Prune() = %+v, want %+v", result, test.want)
+			}
+
+			left, err := scores.QueryScores(ctx, internal.ScoreQuery{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var kept []string
+			for _, score := range left {
+				kept = append(kept, score.Session)
+			}
+
+			if len(kept) != len(test.kept) {
+				t.Fatalf("scores left = %v, want %v", kept, test.kept)
+			}
+
+			for index := range kept {
+				if kept[index] != test.kept[index] {
+					t.Fatalf("scores left = %v, want %v", kept, test.kept)
+				}
+			}
+		})
+	}
+}
+
+func TestReset(t *testing.T) {
+	ctx := context.Background()
+	db := openDB(t)
+
+	players := internal.NewPlayerService(db)
+	if _, err := players.UsePlayer(ctx, "alice"); err != nil {
+		t.Fatal(err)
+	}
+
+	sessions, err := internal.NewSQLiteSessionManager("alice", db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scores := internal.NewScoreService("alice", sessions, db)
+	if err := scores.SetCurrentScore(ctx, 40, time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := internal.Reset(ctx, db); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, table := range []string{"scores", "replays", "sessions", "game_stats", "achievements"} {
+		var count int
+		if err := db.QueryRow(`select count(*) from ` + table).Scan(&count); err != nil {
+			t.Fatal(err)
+		}
+
+		if count != 0 {
+			t.Fatalf("%s has %d rows after a reset, want none", table, count)
+		}
+	}
+
+	left, err := players.GetPlayers(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(left) != 1 || left[0].Name != "alice" {
+		t.Fatalf("players after a reset = %+v, want alice's profile kept", left)
+	}
+}
@@ -0,0 +1 @@
+create table if not exists scores (id integer not null primary key autoincrement, "user" text, session text unique, value integer, created_at datetime default current_timestamp);
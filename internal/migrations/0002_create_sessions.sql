@@ -0,0 +1 @@
+create table if not exists sessions (id text not null primary key, "user" text, level integer not null default 0, seed integer not null default 0, state text not null default 'active', started_at datetime default current_timestamp, ended_at datetime);
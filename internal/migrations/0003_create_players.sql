@@ -0,0 +1,3 @@
+create table if not exists players (id integer not null primary key autoincrement, name text not null unique, created_at datetime default current_timestamp, last_active_at datetime default current_timestamp);
+
+insert or ignore into players (name) select distinct "user" from scores where "user" is not null and "user" != '';
@@ -0,0 +1 @@
+alter table scores add column name text not null default '';
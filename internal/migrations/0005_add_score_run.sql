@@ -0,0 +1,3 @@
+alter table scores add column level integer not null default 0;
+alter table scores add column mode text not null default 'classic';
+alter table scores add column board text not null default '';
@@ -0,0 +1 @@
+alter table scores add column duration_ms integer not null default 0;
@@ -0,0 +1,3 @@
+create table if not exists game_stats (session text not null primary key, "user" text not null, level integer not null default 0, score integer not null default 0, food_eaten integer not null default 0, snake_length integer not null default 0, duration_ms integer not null default 0, death_cause text not null default '', created_at datetime default current_timestamp, updated_at datetime default current_timestamp);
+
+create index if not exists game_stats_user on game_stats ("user", updated_at);
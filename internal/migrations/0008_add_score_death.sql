@@ -0,0 +1,5 @@
+alter table scores add column death_cause text not null default '';
+alter table scores add column death_x integer not null default 0;
+alter table scores add column death_y integer not null default 0;
+
+create index if not exists scores_death on scores (death_cause, level, death_x, death_y);
@@ -0,0 +1 @@
+create table if not exists achievements (player text not null, achievement_id text not null, session text not null default '', unlocked_at datetime default current_timestamp, primary key (player, achievement_id));
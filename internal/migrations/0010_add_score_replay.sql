@@ -0,0 +1,3 @@
+alter table scores add column verification text not null default '';
+
+create table if not exists replays (session text not null, level integer not null, seed integer not null, start_score integer not null default 0, ticks integer not null default 0, inputs text not null default '[]', updated_at datetime default current_timestamp, primary key (session, level));
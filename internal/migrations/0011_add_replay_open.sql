@@ -0,0 +1 @@
+alter table replays add column open integer not null default 0;
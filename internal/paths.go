@@ -0,0 +1,138 @@
+package internal
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/the-Jinxist/golang_snake_game/utils"
+)
+
+const (
+	appDirName    = "super_snake"
+	dbFileName    = "scores.db"
+	jsonFileName  = "scores.json"
+	legacyDBPath  = "./my.db"
+	dbPathEnvName = "SUPER_SNAKE_DB"
+	// jsonPathEnvName is separate from dbPathEnvName so a SQLite database
+	// is never opened as a JSON store.
+	jsonPathEnvName = "SUPER_SNAKE_JSON_STORE"
+)
+
+// DataDir returns the directory super_snake keeps its data in, following
+// $XDG_DATA_HOME and defaulting to ~/.local/share/super_snake.
+func DataDir() (string, error) {
+	base := os.Getenv("XDG_DATA_HOME")
+	if base == "" && utils.IsWindowsMachine() {
+		base = os.Getenv("LOCALAPPDATA")
+	}
+
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+
+		base = filepath.Join(home, ".local", "share")
+	}
+
+	dir := filepath.Join(base, appDirName)
+	return dir, os.MkdirAll(dir, 0o755)
+}
+
+// ResolveDBPath picks the database location from the --db flag, then the
+// SUPER_SNAKE_DB environment variable, then the data directory. When falling
+// back to the data directory an existing ./my.db is moved there first.
+func ResolveDBPath(flagPath string) (string, error) {
+	if flagPath != "" {
+		return flagPath, nil
+	}
+
+	if envPath := os.Getenv(dbPathEnvName); envPath != "" {
+		return envPath, nil
+	}
+
+	dir, err := DataDir()
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, dbFileName)
+	return path, migrateLegacyDB(path)
+}
+
+// ResolveJSONStorePath picks the JSON store location from the --db flag,
+// then the SUPER_SNAKE_JSON_STORE environment variable, defaulting to
+// scores.json in the data directory.
+func ResolveJSONStorePath(flagPath string) (string, error) {
+	if flagPath != "" {
+		return flagPath, nil
+	}
+
+	if envPath := os.Getenv(jsonPathEnvName); envPath != "" {
+		return envPath, nil
+	}
+
+	dir, err := DataDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, jsonFileName), nil
+}
+
+// absPath makes path absolute so the same store is named the same way from
+// any working directory, keeping path as it is if that fails.
+func absPath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+
+	return abs
+}
+
+func migrateLegacyDB(path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+
+	if _, err := os.Stat(legacyDBPath); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err := os.Rename(legacyDBPath, path); err == nil {
+		return nil
+	}
+
+	// Rename fails across filesystems, so fall back to copying.
+	err := copyFile(legacyDBPath, path)
+	if err != nil {
+		return err
+	}
+
+	return os.Remove(legacyDBPath)
+}
+
+func copyFile(from, to string) error {
+	src, err := os.Open(from)
+	if err != nil {
+		return err
+	}
+
+	defer src.Close()
+
+	dst, err := os.OpenFile(to, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		os.Remove(to)
+		return err
+	}
+
+	return dst.Close()
+}
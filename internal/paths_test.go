@@ -0,0 +1,128 @@
+package internal_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/the-Jinxist/golang_snake_game/internal"
+)
+
+func TestResolveDBPath(t *testing.T) {
+	data := t.TempDir()
+
+	tests := []struct {
+		name string
+		flag string
+		env  string
+		want string
+	}{
+		{name: "flag", flag: "flag.db", env: "env.db", want: "flag.db"},
+		{name: "environment", env: "env.db", want: "env.db"},
+		{name: "data directory", want: filepath.Join(data, "super_snake", "scores.db")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			t.Setenv("XDG_DATA_HOME", data)
+			t.Setenv("SUPER_SNAKE_DB", test.env)
+
+			got, err := internal.ResolveDBPath(test.flag)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != test.want {
+				t.Fatalf("ResolveDBPath(%q) = %q, want %q", test.flag, got, test.want)
+			}
+		})
+	}
+}
+
+func TestResolveDBPathMovesLegacyDB(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	t.Setenv("SUPER_SNAKE_DB", "")
+
+	if err := os.WriteFile("my.db", []byte("scores"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	path, err := internal.ResolveDBPath("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if data, err := os.ReadFile(path); err != nil || string(data) != "scores" {
+		t.Fatalf("%s = %q, %v; want the legacy database moved there", path, data, err)
+	}
+
+	if _, err := os.Stat("my.db"); !os.IsNotExist(err) {
+		t.Fatalf("./my.db is still there: %v", err)
+	}
+}
+
+func TestResolveDBPathKeepsExistingDB(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	t.Setenv("SUPER_SNAKE_DB", "")
+
+	path, err := internal.ResolveDBPath("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(path, []byte("current"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile("my.db", []byte("legacy"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := internal.ResolveDBPath(""); err != nil {
+		t.Fatal(err)
+	}
+
+	if data, _ := os.ReadFile(path); string(data) != "current" {
+		t.Fatalf("%s = %q, want the existing database left alone", path, data)
+	}
+
+	if _, err := os.Stat("my.db"); err != nil {
+		t.Fatalf("./my.db was touched: %v", err)
+	}
+}
+
+func TestResolveJSONStorePath(t *testing.T) {
+	data := t.TempDir()
+
+	tests := []struct {
+		name string
+		flag string
+		env  string
+		want string
+	}{
+		{name: "flag", flag: "flag.json", env: "env.json", want: "flag.json"},
+		{name: "environment", env: "env.json", want: "env.json"},
+		{name: "data directory", want: filepath.Join(data, "super_snake", "scores.json")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("XDG_DATA_HOME", data)
+			// The SQLite variable must never point the JSON store at a database.
+			t.Setenv("SUPER_SNAKE_DB", "scores.db")
+			t.Setenv("SUPER_SNAKE_JSON_STORE", test.env)
+
+			got, err := internal.ResolveJSONStorePath(test.flag)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != test.want {
+				t.Fatalf("ResolveJSONStorePath(%q) = %q, want %q", test.flag, got, test.want)
+			}
+		})
+	}
+}
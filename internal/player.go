@@ -0,0 +1,135 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var _ PlayerService = &PlayerServiceImpl{}
+
+const maxPlayerNameLength = 20
+
+var (
+	ErrPlayerExists      = errors.New("a player with that name already exists")
+	ErrInvalidPlayerName = fmt.Errorf("player names must be 1-%d characters", maxPlayerNameLength)
+)
+
+type Player struct {
+	ID           int       `db:"id"`
+	Name         string    `db:"name"`
+	CreatedAt    time.Time `db:"created_at"`
+	LastActiveAt time.Time `db:"last_active_at"`
+}
+
+type PlayerService interface {
+	GetPlayers(ctx context.Context) ([]Player, error)
+	CreatePlayer(ctx context.Context, name string) (Player, error)
+	UsePlayer(ctx context.Context, name string) (Player, error)
+	GetLastActivePlayer(ctx context.Context) (Player, error)
+}
+
+func NewPlayerService(db *sql.DB) PlayerService {
+	return &PlayerServiceImpl{
+		db: db,
+	}
+}
+
+type PlayerServiceImpl struct {
+	db *sql.DB
+}
+
+// GetPlayers implements PlayerService.
+func (p *PlayerServiceImpl) GetPlayers(ctx context.Context) ([]Player, error) {
+	players := make([]Player, 0)
+
+	rows, err := p.db.QueryContext(ctx, `select id, name, created_at, last_active_at from players order by last_active_at desc`)
+	if err != nil {
+		return players, err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var player Player
+		err = rows.Scan(&player.ID, &player.Name, &player.CreatedAt, &player.LastActiveAt)
+		if err != nil {
+			return players, err
+		}
+
+		players = append(players, player)
+	}
+
+	return players, rows.Err()
+}
+
+// CreatePlayer implements PlayerService.
+func (p *PlayerServiceImpl) CreatePlayer(ctx context.Context, name string) (Player, error) {
+	name, err := normalizePlayerName(name)
+	if err != nil {
+		return Player{}, err
+	}
+
+	result, err := p.db.ExecContext(ctx, `insert or ignore into players (name) values (?)`, name)
+	if err != nil {
+		return Player{}, err
+	}
+
+	if created, _ := result.RowsAffected(); created == 0 {
+		return Player{}, ErrPlayerExists
+	}
+
+	return p.getPlayer(ctx, name)
+}
+
+// UsePlayer implements PlayerService. The player is created if needed and
+// marked as the most recently active one.
+func (p *PlayerServiceImpl) UsePlayer(ctx context.Context, name string) (Player, error) {
+	name, err := normalizePlayerName(name)
+	if err != nil {
+		return Player{}, err
+	}
+
+	// Milliseconds, so switching back and forth within a second still
+	// leaves the right player as the last active one.
+	_, err = p.db.ExecContext(ctx, `
+	insert into players (name, last_active_at) values (?, strftime('%Y-%m-%d %H:%M:%f', 'now'))
+	on conflict(name) do update set last_active_at = excluded.last_active_at`, name)
+	if err != nil {
+		return Player{}, err
+	}
+
+	return p.getPlayer(ctx, name)
+}
+
+// GetLastActivePlayer implements PlayerService.
+func (p *PlayerServiceImpl) GetLastActivePlayer(ctx context.Context) (Player, error) {
+	var player Player
+
+	err := p.db.QueryRowContext(ctx,
+		`select id, name, created_at, last_active_at from players order by last_active_at desc, id desc limit 1`,
+	).Scan(&player.ID, &player.Name, &player.CreatedAt, &player.LastActiveAt)
+
+	return player, err
+}
+
+func (p *PlayerServiceImpl) getPlayer(ctx context.Context, name string) (Player, error) {
+	var player Player
+
+	err := p.db.QueryRowContext(ctx,
+		`select id, name, created_at, last_active_at from players where name = ?`, name,
+	).Scan(&player.ID, &player.Name, &player.CreatedAt, &player.LastActiveAt)
+
+	return player, err
+}
+
+func normalizePlayerName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len([]rune(name)) > maxPlayerNameLength {
+		return name, ErrInvalidPlayerName
+	}
+
+	return name, nil
+}
@@ -0,0 +1,79 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/the-Jinxist/golang_snake_game/internal"
+)
+
+func TestCreatePlayer(t *testing.T) {
+	tests := []struct {
+		name    string
+		player  string
+		want    string
+		wantErr error
+	}{
+		{name: "new player", player: "alice", want: "alice"},
+		{name: "trimmed", player: "  bob  ", want: "bob"},
+		{name: "taken", player: "carol", wantErr: internal.ErrPlayerExists},
+		{name: "empty", player: "   ", wantErr: internal.ErrInvalidPlayerName},
+		{name: "too long", player: strings.Repeat("x", 21), wantErr: internal.ErrInvalidPlayerName},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			players := internal.NewPlayerService(openDB(t))
+			ctx := context.Background()
+
+			if _, err := players.CreatePlayer(ctx, "carol"); err != nil {
+				t.Fatal(err)
+			}
+
+			player, err := players.CreatePlayer(ctx, test.player)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("CreatePlayer(%q) = %v, want %v", test.player, err, test.wantErr)
+			}
+
+			if err == nil && player.Name != test.want {
+				t.Fatalf("CreatePlayer(%q) created %q, want %q", test.player, player.Name, test.want)
+			}
+		})
+	}
+}
+
+func TestUsePlayer(t *testing.T) {
+	players := internal.NewPlayerService(openDB(t))
+	ctx := context.Background()
+
+	for _, name := range []string{"alice", "bob", "alice"} {
+		if _, err := players.UsePlayer(ctx, name); err != nil {
+			t.Fatal(err)
+		}
+
+		// Switching players by hand takes well over the millisecond the
+		// activity timestamps are kept to.
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	all, err := players.GetPlayers(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(all) != 2 {
+		t.Fatalf("%d players, want alice and bob once each", len(all))
+	}
+
+	last, err := players.GetLastActivePlayer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if last.Name != "alice" {
+		t.Fatalf("last active player = %q, want alice", last.Name)
+	}
+}
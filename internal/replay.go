@@ -0,0 +1,112 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+)
+
+// Verification records whether a leaderboard server re-simulated a score from
+// its replay.
+type Verification string
+
+const (
+	Unverified Verification = ""
+	Verified   Verification = "verified"
+)
+
+// ReplayInput is a turn taken before the snake's Tick-th move.
+type ReplayInput struct {
+	Tick      int `json:"tick"`
+	Direction int `json:"direction"`
+}
+
+// LevelReplay is everything needed to re-simulate one level of a run: the
+// seed food placement was drawn from, the score carried in from the previous
+// level, how many moves were made and the turns taken along the way. Open
+// records that the board was played without walls, which players may choose
+// for the warm-up board.
+type LevelReplay struct {
+	Level      int           `json:"level"`
+	Seed       uint64        `json:"seed"`
+	StartScore int           `json:"start_score"`
+	Ticks      int           `json:"ticks"`
+	Inputs     []ReplayInput `json:"inputs"`
+	Open       bool          `json:"open,omitempty"`
+}
+
+// execer is satisfied by both *sql.DB and *sql.Tx.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// SetCurrentReplay implements ScoreService. It stores the replay of the
+// current session's level, replacing what was recorded for it so far.
+func (s *ScoreServiceImpol) SetCurrentReplay(ctx context.Context, replay LevelReplay) error {
+	session, err := s.Session.GetCurrentSession()
+	if err != nil {
+		return storeError("set current replay", err)
+	}
+
+	return storeError("set current replay", saveReplay(ctx, s.db, session, replay))
+}
+
+// GetReplay implements ScoreService. Levels are returned in the order they
+// were played.
+func (s *ScoreServiceImpol) GetReplay(ctx context.Context, session string) ([]LevelReplay, error) {
+	replay := make([]LevelReplay, 0)
+
+	rows, err := s.db.QueryContext(ctx,
+		`select level, seed, start_score, ticks, inputs, open from replays where session = ? order by level`, session,
+	)
+	if err != nil {
+		return replay, storeError("get replay", err)
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			level  LevelReplay
+			seed   int64
+			inputs string
+		)
+
+		if err := rows.Scan(&level.Level, &seed, &level.StartScore, &level.Ticks, &inputs, &level.Open); err != nil {
+			return replay, storeError("get replay", err)
+		}
+
+		if err := json.Unmarshal([]byte(inputs), &level.Inputs); err != nil {
+			return replay, storeError("get replay", err)
+		}
+
+		level.Seed = uint64(seed)
+		replay = append(replay, level)
+	}
+
+	return replay, storeError("get replay", rows.Err())
+}
+
+func saveReplay(ctx context.Context, db execer, session string, replay LevelReplay) error {
+	if replay.Inputs == nil {
+		replay.Inputs = []ReplayInput{}
+	}
+
+	inputs, err := json.Marshal(replay.Inputs)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, `
+	insert into replays (session, level, seed, start_score, ticks, inputs, open)
+	values (?, ?, ?, ?, ?, ?, ?)
+	on conflict(session, level) do update set
+		seed = excluded.seed,
+		start_score = excluded.start_score,
+		ticks = excluded.ticks,
+		inputs = excluded.inputs,
+		open = excluded.open,
+		updated_at = current_timestamp`,
+		session, replay.Level, int64(replay.Seed), replay.StartScore, replay.Ticks, string(inputs), replay.Open,
+	)
+	return err
+}
@@ -3,25 +3,107 @@ package internal
 import (
 	"context"
 	"database/sql"
-	"log"
 	"time"
 )
 
 var _ ScoreService = &ScoreServiceImpol{}
 
 type Score struct {
-	ID        int       `db:"id"`
-	User      string    `db:"user"`
-	Session   string    `db:"session"`
-	Value     int       `db:"value"`
-	CreatedAt time.Time `db:"created_at"`
+	ID           int           `db:"id"`
+	User         string        `db:"user"`
+	Name         string        `db:"name"`
+	Session      string        `db:"session"`
+	Value        int           `db:"value"`
+	Level        int           `db:"level"`
+	Mode         string        `db:"mode"`
+	Board        string        `db:"board"`
+	Duration     time.Duration `db:"duration_ms"`
+	DeathCause   DeathCause    `db:"death_cause"`
+	DeathX       int           `db:"death_x"`
+	DeathY       int           `db:"death_y"`
+	Verification Verification  `db:"verification"`
+	Rank         int           `db:"rank"`
+	CreatedAt    time.Time     `db:"created_at"`
+}
+
+const (
+	GameModeClassic    = "classic"
+	GameModeTimeAttack = "time_attack"
+	GameModeDaily      = "daily"
+)
+
+// RunInfo describes the run a score belongs to: the level reached, the game
+// mode and the board it was played on.
+type RunInfo struct {
+	Level int
+	Mode  string
+	Board string
+}
+
+// DisplayName is the name entered on the high-score screen, or the player
+// profile when none was entered.
+func (s Score) DisplayName() string {
+	if s.Name != "" {
+		return s.Name
+	}
+
+	return s.User
+}
+
+const scoreColumns = `id, "user", name, session, value, level, mode, board, duration_ms, death_cause, death_x, death_y, verification, created_at`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanScore(row rowScanner, score *Score, extra ...any) error {
+	var durationMs int64
+
+	dest := []any{
+		&score.ID,
+		&score.User,
+		&score.Name,
+		&score.Session,
+		&score.Value,
+		&score.Level,
+		&score.Mode,
+		&score.Board,
+		&durationMs,
+		&score.DeathCause,
+		&score.DeathX,
+		&score.DeathY,
+		&score.Verification,
+		&score.CreatedAt,
+	}
+
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return err
+	}
+
+	score.Duration = time.Duration(durationMs) * time.Millisecond
+	return nil
 }
 
 type ScoreService interface {
 	GetHighScore(ctx context.Context) (Score, error)
 	GetScores(ctx context.Context) ([]Score, error)
-	SetCurrentScore(ctx context.Context, value int) error
-	GetCurrentScore(ctx context.Context) (int, error)
+	QueryScores(ctx context.Context, query ScoreQuery) ([]Score, error)
+	CountScores(ctx context.Context, query ScoreQuery) (int, error)
+	ImportScores(ctx context.Context, scores []Score) (int, error)
+	SubmitScore(ctx context.Context, score Score, replay []LevelReplay) error
+	SetCurrentReplay(ctx context.Context, replay LevelReplay) error
+	GetReplay(ctx context.Context, session string) ([]LevelReplay, error)
+	SetCurrentScore(ctx context.Context, value int, played time.Duration) error
+	SetCurrentRun(ctx context.Context, run RunInfo) error
+	GetCurrentScore(ctx context.Context) (Score, error)
+	SetCurrentScoreName(ctx context.Context, name string) error
+	SetCurrentDeath(ctx context.Context, cause DeathCause, x, y int) error
+	GetDeathHotspots(ctx context.Context, query DeathQuery) ([]DeathHotspot, error)
+	GetSessions(ctx context.Context) ([]Session, error)
+	GetPersonalBest(ctx context.Context) (Score, error)
+	GetHistory(ctx context.Context, limit int) ([]Score, error)
+	SetCurrentUser(user string)
+	GetCurrentUser() string
 }
 
 func NewScoreService(user string, sessionMgr SessionManager, db *sql.DB) ScoreService {
@@ -29,6 +111,7 @@ func NewScoreService(user string, sessionMgr SessionManager, db *sql.DB) ScoreSe
 		db:          db,
 		CurrentUser: user,
 		Session:     sessionMgr,
+		run:         RunInfo{Mode: GameModeClassic},
 	}
 }
 
@@ -36,31 +119,25 @@ type ScoreServiceImpol struct {
 	CurrentUser string
 	db          *sql.DB
 	Session     SessionManager
+	run         RunInfo
 }
 
 // GetHighScore implements ScoreService.
 func (s *ScoreServiceImpol) GetHighScore(ctx context.Context) (Score, error) {
 	var score Score
 
-	err := s.db.QueryRowContext(ctx, `select * from scores order by value desc limit 1`).Scan(
-		&score.ID,
-		&score.User,
-		&score.Session,
-		&score.Value,
-		&score.CreatedAt,
-	)
+	err := scanScore(s.db.QueryRowContext(ctx, `select `+scoreColumns+` from scores order by value desc limit 1`), &score)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			session, _ := s.Session.GetCurrentSession()
+			// Reading the board is not a run, so no session is started.
 			return Score{
 				User:      s.CurrentUser,
-				Session:   session,
 				Value:     0,
 				CreatedAt: time.Now(),
 			}, nil
 		}
-		return score, nil
+		return score, storeError("get high score", err)
 	}
 
 	return score, nil
@@ -71,52 +148,85 @@ func (s *ScoreServiceImpol) GetScores(ctx context.Context) ([]Score, error) {
 
 	scores := make([]Score, 0, 5)
 
-	rows, err := s.db.QueryContext(ctx, `select * from scores order by value desc limit 5`)
+	rows, err := s.db.QueryContext(ctx, `select `+scoreColumns+` from scores order by value desc limit 5`)
 	if err != nil {
-		return scores, nil
+		return scores, storeError("get scores", err)
 	}
 
 	defer rows.Close()
 	for rows.Next() {
 		var score Score
-		err = rows.Scan(
-			&score.ID,
-			&score.User,
-			&score.Session,
-			&score.Value,
-			&score.CreatedAt,
-		)
+		err = scanScore(rows, &score)
 		if err != nil {
-			log.Printf("issue with scanning each rows: %s", err)
-			return scores, err
+			return scores, storeError("get scores", err)
 		}
 
 		scores = append(scores, score)
 	}
 
-	if err := rows.Err(); err != nil {
-		log.Printf("Error encountered while iterating over rows: %s", err)
+	return scores, storeError("get scores", rows.Err())
+}
+
+// GetPersonalBest implements ScoreService.
+func (s *ScoreServiceImpol) GetPersonalBest(ctx context.Context) (Score, error) {
+	var score Score
+
+	err := scanScore(s.db.QueryRowContext(ctx, `select `+scoreColumns+` from scores where "user" = ? order by value desc limit 1`, s.CurrentUser), &score)
+
+	if err != nil && err != sql.ErrNoRows {
+		return score, storeError("get personal best", err)
 	}
 
-	return scores, nil
+	return score, nil
+}
+
+// GetHistory implements ScoreService. It returns the current user's most
+// recent scores, newest first.
+func (s *ScoreServiceImpol) GetHistory(ctx context.Context, limit int) ([]Score, error) {
+	scores := make([]Score, 0, limit)
+
+	rows, err := s.db.QueryContext(ctx, `select `+scoreColumns+` from scores where "user" = ? order by created_at desc, id desc limit ?`, s.CurrentUser, limit)
+	if err != nil {
+		return scores, storeError("get history", err)
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var score Score
+		err = scanScore(rows, &score)
+		if err != nil {
+			return scores, storeError("get history", err)
+		}
+
+		scores = append(scores, score)
+	}
+
+	return scores, storeError("get history", rows.Err())
+}
 
+// SetCurrentUser implements ScoreService.
+func (s *ScoreServiceImpol) SetCurrentUser(user string) {
+	s.CurrentUser = user
 }
 
-func (s *ScoreServiceImpol) GetCurrentScore(ctx context.Context) (int, error) {
+// GetCurrentUser implements ScoreService.
+func (s *ScoreServiceImpol) GetCurrentUser() string {
+	return s.CurrentUser
+}
+
+func (s *ScoreServiceImpol) GetCurrentScore(ctx context.Context) (Score, error) {
 	session, _ := s.Session.GetCurrentSession()
-	var score int
+	var score Score
 
-	err := s.db.QueryRowContext(ctx,
-		`select value from scores where session = ?`, session,
-	).Scan(
-		&score,
-	)
+	err := scanScore(s.db.QueryRowContext(ctx,
+		`select `+scoreColumns+` from scores where session = ?`, session,
+	), &score)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return score, nil
 		}
-		return score, err
+		return score, storeError("get current score", err)
 	}
 
 	return score, nil
@@ -124,18 +234,155 @@ func (s *ScoreServiceImpol) GetCurrentScore(ctx context.Context) (int, error) {
 }
 
 // SetScore implements ScoreService.
-func (s *ScoreServiceImpol) SetCurrentScore(ctx context.Context, value int) error {
+func (s *ScoreServiceImpol) SetCurrentScore(ctx context.Context, value int, played time.Duration) error {
 
 	session, _ := s.Session.GetCurrentSession()
 	_, err := s.db.ExecContext(ctx,
-		`insert into scores ("user", session, value) 
-	 values (?, ?, ?)
+		`insert into scores ("user", session, value, level, mode, board, duration_ms) 
+	 values (?, ?, ?, ?, ?, ?, ?)
 	 on conflict(session) do update set
-		value = excluded.value
+		value = excluded.value,
+		level = excluded.level,
+		mode = excluded.mode,
+		board = excluded.board,
+		duration_ms = excluded.duration_ms
 	where scores.session = excluded.session and scores."user" = excluded."user";
-	 `, s.CurrentUser, session, value)
+	 `, s.CurrentUser, session, value, s.run.Level, s.run.Mode, s.run.Board, played.Milliseconds())
+	return storeError("set current score", err)
+}
+
+// SetCurrentRun implements ScoreService. Later score writes are tagged with
+// run, and an existing score for the session is updated to match.
+func (s *ScoreServiceImpol) SetCurrentRun(ctx context.Context, run RunInfo) error {
+	if run.Mode == "" {
+		run.Mode = GameModeClassic
+	}
+
+	s.run = run
+
+	session, err := s.Session.GetCurrentSession()
 	if err != nil {
-		return err
+		return storeError("set current run", err)
 	}
-	return nil
+
+	_, err = s.db.ExecContext(ctx,
+		`update scores set level = ?, mode = ?, board = ? where session = ?`,
+		run.Level, run.Mode, run.Board, session,
+	)
+	return storeError("set current run", err)
+}
+
+// SetCurrentScoreName implements ScoreService. It records the name entered on
+// the high-score screen against the current session's score.
+func (s *ScoreServiceImpol) SetCurrentScoreName(ctx context.Context, name string) error {
+	session, err := s.Session.GetCurrentSession()
+	if err != nil {
+		return storeError("set score name", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `update scores set name = ? where session = ?`, name, session)
+	return storeError("set score name", err)
+}
+
+// SubmitScore implements ScoreService. It records a complete score sent by
+// another client, updating the existing row for the session if the same
+// player submitted it before, and stores the run's replay alongside it. A
+// session that belongs to another player is left alone, replay and all, and
+// ErrSessionTaken is returned.
+func (s *ScoreServiceImpol) SubmitScore(ctx context.Context, score Score, replay []LevelReplay) error {
+	if score.Mode == "" {
+		score.Mode = GameModeClassic
+	}
+
+	createdAt := score.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return storeError("submit score", err)
+	}
+
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+	insert into scores ("user", name, session, value, level, mode, board, duration_ms, death_cause, death_x, death_y, verification, created_at)
+	values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	on conflict(session) do update set
+		name = excluded.name,
+		value = excluded.value,
+		level = excluded.level,
+		mode = excluded.mode,
+		board = excluded.board,
+		duration_ms = excluded.duration_ms,
+		death_cause = excluded.death_cause,
+		death_x = excluded.death_x,
+		death_y = excluded.death_y,
+		verification = excluded.verification
+	where scores."user" = excluded."user"`,
+		score.User, score.Name, score.Session, score.Value, score.Level, score.Mode, score.Board,
+		score.Duration.Milliseconds(), score.DeathCause, score.DeathX, score.DeathY, score.Verification,
+		createdAt.UTC().Format(sqliteTimeLayout),
+	)
+	if err != nil {
+		return storeError("submit score", err)
+	}
+
+	if saved, err := result.RowsAffected(); err != nil {
+		return storeError("submit score", err)
+	} else if saved == 0 {
+		return ErrSessionTaken
+	}
+
+	for _, level := range replay {
+		if err := saveReplay(ctx, tx, score.Session, level); err != nil {
+			return storeError("submit score", err)
+		}
+	}
+
+	return storeError("submit score", tx.Commit())
+}
+
+// GetSessions implements ScoreService.
+func (s *ScoreServiceImpol) GetSessions(ctx context.Context) ([]Session, error) {
+	sessions := make([]Session, 0)
+
+	rows, err := s.db.QueryContext(ctx, `
+	select sessions.id, sessions."user", sessions.level, sessions.seed, sessions.state,
+		coalesce(scores.value, 0), sessions.started_at, sessions.ended_at
+	from sessions left join scores on scores.session = sessions.id
+	order by sessions.started_at desc`)
+	if err != nil {
+		return sessions, storeError("get sessions", err)
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			session Session
+			seed    int64
+			endedAt sql.NullTime
+		)
+
+		err = rows.Scan(
+			&session.ID,
+			&session.User,
+			&session.Level,
+			&seed,
+			&session.State,
+			&session.Score,
+			&session.StartedAt,
+			&endedAt,
+		)
+		if err != nil {
+			return sessions, storeError("get sessions", err)
+		}
+
+		session.Seed = uint64(seed)
+		session.EndedAt = endedAt.Time
+		sessions = append(sessions, session)
+	}
+
+	return sessions, storeError("get sessions", rows.Err())
 }
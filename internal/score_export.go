@@ -0,0 +1,242 @@
+package internal
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+const sqliteTimeLayout = "2006-01-02 15:04:05"
+
+// ScoreRecord is the portable JSON/CSV form of a Score, used for export,
+// import and the leaderboard server API.
+type ScoreRecord struct {
+	Rank         int           `json:"rank,omitempty"`
+	User         string        `json:"user"`
+	Name         string        `json:"name"`
+	Session      string        `json:"session"`
+	Value        int           `json:"value"`
+	Level        int           `json:"level"`
+	Mode         string        `json:"mode"`
+	Board        string        `json:"board"`
+	DurationMs   int64         `json:"duration_ms"`
+	DeathCause   string        `json:"death_cause"`
+	DeathX       int           `json:"death_x"`
+	DeathY       int           `json:"death_y"`
+	Verification Verification  `json:"verification,omitempty"`
+	CreatedAt    time.Time     `json:"created_at"`
+	Replay       []LevelReplay `json:"replay,omitempty"`
+}
+
+var csvHeader = []string{"user", "name", "session", "value", "level", "mode", "board", "duration_ms", "death_cause", "death_x", "death_y", "created_at"}
+
+// NewScoreRecord converts score to its portable form.
+func NewScoreRecord(score Score) ScoreRecord {
+	return ScoreRecord{
+		Rank:         score.Rank,
+		User:         score.User,
+		Name:         score.Name,
+		Session:      score.Session,
+		Value:        score.Value,
+		Level:        score.Level,
+		Mode:         score.Mode,
+		Board:        score.Board,
+		DurationMs:   score.Duration.Milliseconds(),
+		DeathCause:   string(score.DeathCause),
+		DeathX:       score.DeathX,
+		DeathY:       score.DeathY,
+		Verification: score.Verification,
+		CreatedAt:    score.CreatedAt.UTC(),
+	}
+}
+
+// Score converts the record back to a Score.
+func (r ScoreRecord) Score() Score {
+	return Score{
+		Rank:         r.Rank,
+		User:         r.User,
+		Name:         r.Name,
+		Session:      r.Session,
+		Value:        r.Value,
+		Level:        r.Level,
+		Mode:         r.Mode,
+		Board:        r.Board,
+		Duration:     time.Duration(r.DurationMs) * time.Millisecond,
+		DeathCause:   DeathCause(r.DeathCause),
+		DeathX:       r.DeathX,
+		DeathY:       r.DeathY,
+		Verification: r.Verification,
+		CreatedAt:    r.CreatedAt,
+	}
+}
+
+// WriteScores encodes scores to w as JSON or CSV.
+func WriteScores(w io.Writer, format string, scores []Score) error {
+	switch format {
+	case FormatJSON:
+		records := make([]ScoreRecord, 0, len(scores))
+		for _, score := range scores {
+			records = append(records, NewScoreRecord(score))
+		}
+
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(records)
+	case FormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(csvHeader); err != nil {
+			return err
+		}
+
+		for _, score := range scores {
+			r := NewScoreRecord(score)
+			err := writer.Write([]string{
+				r.User, r.Name, r.Session, strconv.Itoa(r.Value), strconv.Itoa(r.Level), r.Mode, r.Board,
+				strconv.FormatInt(r.DurationMs, 10), r.DeathCause, strconv.Itoa(r.DeathX), strconv.Itoa(r.DeathY),
+				r.CreatedAt.Format(time.RFC3339),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		writer.Flush()
+		return writer.Error()
+	default:
+		return fmt.Errorf("unsupported format %q, use %s or %s", format, FormatJSON, FormatCSV)
+	}
+}
+
+// ReadScores decodes scores written by WriteScores.
+func ReadScores(r io.Reader, format string) ([]Score, error) {
+	switch format {
+	case FormatJSON:
+		var records []ScoreRecord
+		if err := json.NewDecoder(r).Decode(&records); err != nil {
+			return nil, err
+		}
+
+		scores := make([]Score, 0, len(records))
+		for _, record := range records {
+			scores = append(scores, record.Score())
+		}
+
+		return scores, nil
+	case FormatCSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = len(csvHeader)
+
+		rows, err := reader.ReadAll()
+		if err != nil {
+			return nil, err
+		}
+
+		scores := make([]Score, 0, len(rows))
+		for index, row := range rows {
+			if index == 0 {
+				continue
+			}
+
+			record, err := parseCSVRecord(row)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", index+1, err)
+			}
+
+			scores = append(scores, record.Score())
+		}
+
+		return scores, nil
+	default:
+		return nil, fmt.Errorf("unsupported format %q, use %s or %s", format, FormatJSON, FormatCSV)
+	}
+}
+
+func parseCSVRecord(row []string) (ScoreRecord, error) {
+	record := ScoreRecord{
+		User:       row[0],
+		Name:       row[1],
+		Session:    row[2],
+		Mode:       row[5],
+		Board:      row[6],
+		DeathCause: row[8],
+	}
+
+	var err error
+	if record.Value, err = strconv.Atoi(row[3]); err != nil {
+		return record, err
+	}
+
+	if record.Level, err = strconv.Atoi(row[4]); err != nil {
+		return record, err
+	}
+
+	if record.DurationMs, err = strconv.ParseInt(row[7], 10, 64); err != nil {
+		return record, err
+	}
+
+	if record.DeathX, err = strconv.Atoi(row[9]); err != nil {
+		return record, err
+	}
+
+	if record.DeathY, err = strconv.Atoi(row[10]); err != nil {
+		return record, err
+	}
+
+	record.CreatedAt, err = time.Parse(time.RFC3339, row[11])
+	return record, err
+}
+
+// ImportScores implements ScoreService. Scores whose session is already in
+// the database are skipped; the number of scores added is returned. Imported
+// scores are always unverified, whatever the file claims.
+func (s *ScoreServiceImpol) ImportScores(ctx context.Context, scores []Score) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	defer tx.Rollback()
+
+	imported := 0
+	for _, score := range scores {
+		if score.Session == "" {
+			continue
+		}
+
+		if score.Mode == "" {
+			score.Mode = GameModeClassic
+		}
+
+		createdAt := score.CreatedAt
+		if createdAt.IsZero() {
+			createdAt = time.Now()
+		}
+
+		result, err := tx.ExecContext(ctx, `
+		insert into scores ("user", name, session, value, level, mode, board, duration_ms, death_cause, death_x, death_y, created_at)
+		values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		on conflict(session) do nothing`,
+			score.User, score.Name, score.Session, score.Value, score.Level, score.Mode, score.Board,
+			score.Duration.Milliseconds(), score.DeathCause, score.DeathX, score.DeathY,
+			createdAt.UTC().Format(sqliteTimeLayout),
+		)
+		if err != nil {
+			return 0, err
+		}
+
+		if added, _ := result.RowsAffected(); added > 0 {
+			imported++
+		}
+	}
+
+	return imported, tx.Commit()
+}
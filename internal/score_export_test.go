@@ -0,0 +1,66 @@
+package internal_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/the-Jinxist/golang_snake_game/internal"
+)
+
+func TestScoresRoundTrip(t *testing.T) {
+	scores := []internal.Score{
+		{
+			User: "alice", Name: "ACE", Session: "a", Value: 120, Level: 2,
+			Mode: internal.GameModeClassic, Board: "walled", Duration: 95 * time.Second,
+			DeathCause: internal.DeathPillar, DeathX: 4, DeathY: 7,
+			CreatedAt: time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC),
+		},
+		// Commas and quotes must survive CSV.
+		{User: "bob", Name: `"B", the second`, Session: "b", Value: 10, Mode: internal.GameModeDaily, CreatedAt: time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, format := range []string{internal.FormatJSON, internal.FormatCSV} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := internal.WriteScores(&buf, format, scores); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := internal.ReadScores(&buf, format)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, scores) {
+				t.Fatalf("read back %+v, want %+v", got, scores)
+			}
+		})
+	}
+}
+
+func TestReadScoresRejects(t *testing.T) {
+	header := "user,name,session,value,level,mode,board,duration_ms,death_cause,death_x,death_y,created_at\n"
+
+	tests := []struct {
+		name   string
+		format string
+		input  string
+	}{
+		{name: "unknown format", format: "xml", input: "<scores/>"},
+		{name: "broken JSON", format: internal.FormatJSON, input: `[{"user": `},
+		{name: "missing column", format: internal.FormatCSV, input: header + "alice,,a,10,1,classic,,0,,0,0\n"},
+		{name: "value not a number", format: internal.FormatCSV, input: header + "alice,,a,ten,1,classic,,0,,0,0,2026-03-04T05:06:07Z\n"},
+		{name: "bad date", format: internal.FormatCSV, input: header + "alice,,a,10,1,classic,,0,,0,0,yesterday\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if scores, err := internal.ReadScores(strings.NewReader(test.input), test.format); err == nil {
+				t.Fatalf("ReadScores() = %+v, want an error", scores)
+			}
+		})
+	}
+}
@@ -0,0 +1,302 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+var (
+	_ ScoreService   = &jsonScoreService{}
+	_ SessionManager = &jsonSessionManager{}
+)
+
+const jsonStoreVersion = 1
+
+// jsonStoreData is the layout of a JSON store file.
+type jsonStoreData struct {
+	Version  int                      `json:"version"`
+	Scores   []ScoreRecord            `json:"scores"`
+	Replays  map[string][]LevelReplay `json:"replays"`
+	Sessions []Session                `json:"sessions"`
+}
+
+// JSONStore keeps scores and sessions in a single JSON file, for installs
+// that would rather not carry a database. Everything is held in memory and
+// the whole file is rewritten after each change, which is fine for the
+// few hundred games a player records but not for a shared leaderboard.
+//
+// Player profiles, stats and achievements are not kept in a JSON store.
+type JSONStore struct {
+	path string
+
+	mu       sync.Mutex
+	scores   *jsonScoreService
+	sessions *jsonSessionManager
+}
+
+// OpenJSONStore loads the store at path, starting empty if the file does not
+// exist yet. The user's sessions left active by a run that crashed are marked
+// abandoned, as NewSQLiteSessionManager does. Failures wrap
+// ErrStoreUnavailable.
+func OpenJSONStore(path string, user string) (*JSONStore, error) {
+	store := &JSONStore{path: path}
+
+	sessions := &InMemeorySessiomManagerImpl{user: user}
+	store.sessions = &jsonSessionManager{InMemeorySessiomManagerImpl: sessions, store: store}
+	store.scores = &jsonScoreService{
+		InMemoryScoreService: NewInMemoryScoreService(user, store.sessions),
+		store:                store,
+	}
+
+	if err := store.load(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrStoreUnavailable, err)
+	}
+
+	return store, nil
+}
+
+// Scores returns the store's ScoreService.
+func (j *JSONStore) Scores() ScoreService {
+	return j.scores
+}
+
+// Sessions returns the store's SessionManager.
+func (j *JSONStore) Sessions() SessionManager {
+	return j.sessions
+}
+
+func (j *JSONStore) load() error {
+	contents, err := os.ReadFile(j.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	var data jsonStoreData
+	if err := json.Unmarshal(contents, &data); err != nil {
+		return fmt.Errorf("%s is not a score store: %w", j.path, err)
+	}
+
+	if data.Version > jsonStoreVersion {
+		return fmt.Errorf("%s was written by a newer version of super_snake", j.path)
+	}
+
+	scores := j.scores.InMemoryScoreService
+	for _, record := range data.Scores {
+		scores.insert(record.Score())
+	}
+
+	for session, levels := range data.Replays {
+		for _, level := range levels {
+			scores.saveReplay(session, level)
+		}
+	}
+
+	info, err := os.Stat(j.path)
+	if err != nil {
+		return err
+	}
+
+	// The file is rewritten on every change, so when it has not been
+	// written for a while nothing is playing on it; the player's runs still
+	// marked active belong to a game that crashed.
+	stale := time.Since(info.ModTime()) > staleSessionAge
+	abandoned := false
+
+	for _, session := range data.Sessions {
+		if stale && session.State == SessionActive && session.User == j.sessions.user {
+			session.State = SessionAbandoned
+			session.EndedAt = time.Now()
+			abandoned = true
+		}
+
+		j.sessions.sessions = append(j.sessions.sessions, &session)
+	}
+
+	if abandoned {
+		return j.save()
+	}
+
+	return nil
+}
+
+// save rewrites the file from what is in memory. It writes to a temporary
+// file first so a crash never leaves a half-written store behind.
+func (j *JSONStore) save() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	data := jsonStoreData{
+		Version:  jsonStoreVersion,
+		Scores:   j.scores.records(),
+		Replays:  j.scores.allReplays(),
+		Sessions: j.sessions.all(),
+	}
+
+	contents, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return storeError("save "+j.path, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(j.path), 0o755); err != nil {
+		return storeError("save "+j.path, err)
+	}
+
+	tmp := j.path + ".tmp"
+	if err := os.WriteFile(tmp, contents, 0o644); err != nil {
+		return storeError("save "+j.path, err)
+	}
+
+	return storeError("save "+j.path, os.Rename(tmp, j.path))
+}
+
+// saveAfter saves the store if err, the result of a change, is nil.
+func (j *JSONStore) saveAfter(err error) error {
+	if err != nil {
+		return err
+	}
+
+	return j.save()
+}
+
+// jsonScoreService is the in-memory score service, saving after each write.
+type jsonScoreService struct {
+	*InMemoryScoreService
+	store *JSONStore
+}
+
+// ImportScores implements ScoreService.
+func (s *jsonScoreService) ImportScores(ctx context.Context, scores []Score) (int, error) {
+	imported, err := s.InMemoryScoreService.ImportScores(ctx, scores)
+	if err != nil || imported == 0 {
+		return imported, err
+	}
+
+	return imported, s.store.save()
+}
+
+// SubmitScore implements ScoreService.
+func (s *jsonScoreService) SubmitScore(ctx context.Context, score Score, replay []LevelReplay) error {
+	return s.store.saveAfter(s.InMemoryScoreService.SubmitScore(ctx, score, replay))
+}
+
+// SetCurrentReplay implements ScoreService.
+func (s *jsonScoreService) SetCurrentReplay(ctx context.Context, replay LevelReplay) error {
+	return s.store.saveAfter(s.InMemoryScoreService.SetCurrentReplay(ctx, replay))
+}
+
+// SetCurrentScore implements ScoreService.
+func (s *jsonScoreService) SetCurrentScore(ctx context.Context, value int, played time.Duration) error {
+	return s.store.saveAfter(s.InMemoryScoreService.SetCurrentScore(ctx, value, played))
+}
+
+// SetCurrentRun implements ScoreService.
+func (s *jsonScoreService) SetCurrentRun(ctx context.Context, run RunInfo) error {
+	return s.store.saveAfter(s.InMemoryScoreService.SetCurrentRun(ctx, run))
+}
+
+// SetCurrentScoreName implements ScoreService.
+func (s *jsonScoreService) SetCurrentScoreName(ctx context.Context, name string) error {
+	return s.store.saveAfter(s.InMemoryScoreService.SetCurrentScoreName(ctx, name))
+}
+
+// SetCurrentDeath implements ScoreService.
+func (s *jsonScoreService) SetCurrentDeath(ctx context.Context, cause DeathCause, x, y int) error {
+	return s.store.saveAfter(s.InMemoryScoreService.SetCurrentDeath(ctx, cause, x, y))
+}
+
+func (s *jsonScoreService) records() []ScoreRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	records := make([]ScoreRecord, 0, len(s.scores))
+	for _, score := range s.scores {
+		records = append(records, NewScoreRecord(*score))
+	}
+
+	return records
+}
+
+func (s *jsonScoreService) allReplays() map[string][]LevelReplay {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	replays := make(map[string][]LevelReplay, len(s.replays))
+	for session, levels := range s.replays {
+		replays[session] = slices.Clone(levels)
+	}
+
+	return replays
+}
+
+// jsonSessionManager is the in-memory session manager, saving after each
+// change to a session, including one started by asking for the current
+// session.
+type jsonSessionManager struct {
+	*InMemeorySessiomManagerImpl
+	store *JSONStore
+}
+
+// CreateNewSession implements SessionManager.
+func (m *jsonSessionManager) CreateNewSession(value any) (string, error) {
+	session, err := m.InMemeorySessiomManagerImpl.CreateNewSession(value)
+	return session, m.store.saveAfter(err)
+}
+
+// GetCurrentSession implements SessionManager.
+func (m *jsonSessionManager) GetCurrentSession() (string, error) {
+	session, created, err := m.getCurrentSession()
+	if !created {
+		return session, err
+	}
+
+	return session, m.store.save()
+}
+
+// DestroyCurrentSession implements SessionManager.
+func (m *jsonSessionManager) DestroyCurrentSession() error {
+	return m.store.saveAfter(m.InMemeorySessiomManagerImpl.DestroyCurrentSession())
+}
+
+// RestoreSession implements SessionManager.
+func (m *jsonSessionManager) RestoreSession(session string) error {
+	return m.store.saveAfter(m.InMemeorySessiomManagerImpl.RestoreSession(session))
+}
+
+// UpdateCurrentSession implements SessionManager.
+func (m *jsonSessionManager) UpdateCurrentSession(level int, seed uint64) error {
+	return m.store.saveAfter(m.InMemeorySessiomManagerImpl.UpdateCurrentSession(level, seed))
+}
+
+// SuspendCurrentSession implements SessionManager.
+func (m *jsonSessionManager) SuspendCurrentSession() error {
+	return m.store.saveAfter(m.InMemeorySessiomManagerImpl.SuspendCurrentSession())
+}
+
+// FinishCurrentSession implements SessionManager.
+func (m *jsonSessionManager) FinishCurrentSession() error {
+	return m.store.saveAfter(m.InMemeorySessiomManagerImpl.FinishCurrentSession())
+}
+
+// all returns every session, oldest first.
+func (m *jsonSessionManager) all() []Session {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sessions := make([]Session, 0, len(m.sessions))
+	for _, session := range m.sessions {
+		sessions = append(sessions, *session)
+	}
+
+	return sessions
+}
@@ -0,0 +1,106 @@
+package internal_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/the-Jinxist/golang_snake_game/internal"
+)
+
+// storedSessions reads the sessions saved in the JSON store at path.
+func storedSessions(t *testing.T, path string) []internal.Session {
+	t.Helper()
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var data struct {
+		Sessions []internal.Session `json:"sessions"`
+	}
+	if err := json.Unmarshal(contents, &data); err != nil {
+		t.Fatal(err)
+	}
+
+	return data.Sessions
+}
+
+func TestJSONStoreSavesStartedSessions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scores.json")
+
+	store, err := internal.OpenJSONStore(path, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Asking for the current session starts one.
+	session, err := store.Sessions().GetCurrentSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stored := storedSessions(t, path)
+	if len(stored) != 1 || stored[0].ID != session || stored[0].State != internal.SessionActive {
+		t.Fatalf("saved sessions = %+v, want %s saved active", stored, session)
+	}
+}
+
+func TestJSONStoreAbandonsStaleSessions(t *testing.T) {
+	tests := []struct {
+		name string
+		// written is how long ago the store was last written.
+		written time.Duration
+		want    internal.SessionState
+	}{
+		{name: "game still running", written: time.Minute, want: internal.SessionActive},
+		{name: "game that crashed", written: time.Hour, want: internal.SessionAbandoned},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "scores.json")
+
+			for _, player := range []string{"alice", "bob"} {
+				store, err := internal.OpenJSONStore(path, player)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if _, err := store.Sessions().GetCurrentSession(); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			written := time.Now().Add(-test.written)
+			if err := os.Chtimes(path, written, written); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := internal.OpenJSONStore(path, "alice"); err != nil {
+				t.Fatal(err)
+			}
+
+			sessions := storedSessions(t, path)
+			if len(sessions) != 2 {
+				t.Fatalf("saved sessions = %+v, want alice's and bob's", sessions)
+			}
+
+			for _, session := range sessions {
+				want := test.want
+				if session.User == "bob" {
+					// Only the player opening the store has their runs
+					// abandoned.
+					want = internal.SessionActive
+				}
+
+				if session.State != want {
+					t.Fatalf("%s's session saved as %q, want %q", session.User, session.State, want)
+				}
+			}
+		})
+	}
+}
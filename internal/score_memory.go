@@ -0,0 +1,459 @@
+package internal
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+var _ ScoreService = &InMemoryScoreService{}
+
+// InMemoryScoreService keeps scores in memory only and behaves like
+// ScoreServiceImpol otherwise. It backs guest play, where nothing should be
+// written to disk, and tests that need a ScoreService without a database.
+type InMemoryScoreService struct {
+	CurrentUser string
+	Session     SessionManager
+
+	mu      sync.Mutex
+	run     RunInfo
+	scores  []*Score
+	replays map[string][]LevelReplay
+	nextID  int
+}
+
+func NewInMemoryScoreService(user string, sessionMgr SessionManager) *InMemoryScoreService {
+	return &InMemoryScoreService{
+		CurrentUser: user,
+		Session:     sessionMgr,
+		run:         RunInfo{Mode: GameModeClassic},
+		replays:     make(map[string][]LevelReplay),
+	}
+}
+
+// GetHighScore implements ScoreService.
+func (s *InMemoryScoreService) GetHighScore(ctx context.Context) (Score, error) {
+	scores, err := s.QueryScores(ctx, ScoreQuery{Limit: 1})
+	if err != nil || len(scores) == 0 {
+		// Reading the board is not a run, so no session is started.
+		return Score{
+			User:      s.GetCurrentUser(),
+			CreatedAt: time.Now(),
+		}, err
+	}
+
+	return scores[0], nil
+}
+
+// GetScores implements ScoreService.
+func (s *InMemoryScoreService) GetScores(ctx context.Context) ([]Score, error) {
+	return s.QueryScores(ctx, ScoreQuery{Limit: 5})
+}
+
+// QueryScores implements ScoreService.
+func (s *InMemoryScoreService) QueryScores(ctx context.Context, query ScoreQuery) ([]Score, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	matching := s.filter(query)
+
+	for index := range matching {
+		matching[index].Rank = 1
+		for _, other := range matching {
+			if other.Value > matching[index].Value {
+				matching[index].Rank++
+			}
+		}
+	}
+
+	slices.SortStableFunc(matching, func(a, b Score) int {
+		order := compareScores(a, b, query.SortBy)
+		if !query.Ascending {
+			order = -order
+		}
+
+		return cmp.Or(order, cmp.Compare(a.ID, b.ID))
+	})
+
+	if query.Offset > 0 {
+		matching = matching[min(query.Offset, len(matching)):]
+	}
+
+	if query.Limit > 0 {
+		matching = matching[:min(query.Limit, len(matching))]
+	}
+
+	return matching, nil
+}
+
+// CountScores implements ScoreService.
+func (s *InMemoryScoreService) CountScores(ctx context.Context, query ScoreQuery) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.filter(query)), nil
+}
+
+// ImportScores implements ScoreService. Scores whose session is already
+// present are skipped, and imported scores are always unverified.
+func (s *InMemoryScoreService) ImportScores(ctx context.Context, scores []Score) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	imported := 0
+	for _, score := range scores {
+		if score.Session == "" || s.find(score.Session) != nil {
+			continue
+		}
+
+		score.Verification = Unverified
+		s.insert(score)
+		imported++
+	}
+
+	return imported, nil
+}
+
+// SubmitScore implements ScoreService.
+func (s *InMemoryScoreService) SubmitScore(ctx context.Context, score Score, replay []LevelReplay) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing := s.find(score.Session)
+	if existing == nil {
+		s.insert(score)
+	} else if existing.User != score.User {
+		return ErrSessionTaken
+	} else {
+		score.ID, score.CreatedAt = existing.ID, existing.CreatedAt
+		*existing = score
+		if existing.Mode == "" {
+			existing.Mode = GameModeClassic
+		}
+	}
+
+	for _, level := range replay {
+		s.saveReplay(score.Session, level)
+	}
+
+	return nil
+}
+
+// SetCurrentReplay implements ScoreService.
+func (s *InMemoryScoreService) SetCurrentReplay(ctx context.Context, replay LevelReplay) error {
+	session, err := s.Session.GetCurrentSession()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.saveReplay(session, replay)
+	return nil
+}
+
+// GetReplay implements ScoreService.
+func (s *InMemoryScoreService) GetReplay(ctx context.Context, session string) ([]LevelReplay, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.replays[session]), nil
+}
+
+// SetCurrentScore implements ScoreService.
+func (s *InMemoryScoreService) SetCurrentScore(ctx context.Context, value int, played time.Duration) error {
+	session, err := s.Session.GetCurrentSession()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	score := s.currentScore(session)
+	if score == nil {
+		return nil
+	}
+
+	score.Value = value
+	score.Level, score.Mode, score.Board = s.run.Level, s.run.Mode, s.run.Board
+	score.Duration = played
+	return nil
+}
+
+// SetCurrentRun implements ScoreService.
+func (s *InMemoryScoreService) SetCurrentRun(ctx context.Context, run RunInfo) error {
+	if run.Mode == "" {
+		run.Mode = GameModeClassic
+	}
+
+	session, err := s.Session.GetCurrentSession()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.run = run
+	if score := s.find(session); score != nil {
+		score.Level, score.Mode, score.Board = run.Level, run.Mode, run.Board
+	}
+
+	return nil
+}
+
+// GetCurrentScore implements ScoreService.
+func (s *InMemoryScoreService) GetCurrentScore(ctx context.Context) (Score, error) {
+	session, _ := s.Session.GetCurrentSession()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if score := s.find(session); score != nil {
+		return *score, nil
+	}
+
+	return Score{}, nil
+}
+
+// SetCurrentScoreName implements ScoreService.
+func (s *InMemoryScoreService) SetCurrentScoreName(ctx context.Context, name string) error {
+	session, err := s.Session.GetCurrentSession()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if score := s.find(session); score != nil {
+		score.Name = name
+	}
+
+	return nil
+}
+
+// SetCurrentDeath implements ScoreService.
+func (s *InMemoryScoreService) SetCurrentDeath(ctx context.Context, cause DeathCause, x, y int) error {
+	session, err := s.Session.GetCurrentSession()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	score := s.find(session)
+	if score == nil {
+		score = s.insert(Score{
+			User:    s.CurrentUser,
+			Session: session,
+			Level:   s.run.Level,
+			Mode:    s.run.Mode,
+			Board:   s.run.Board,
+		})
+	}
+
+	score.DeathCause, score.DeathX, score.DeathY = cause, x, y
+	return nil
+}
+
+// GetDeathHotspots implements ScoreService.
+func (s *InMemoryScoreService) GetDeathHotspots(ctx context.Context, query DeathQuery) ([]DeathHotspot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hotspots := make([]DeathHotspot, 0)
+	for _, score := range s.scores {
+		if score.DeathCause == DeathNone ||
+			(query.Level != nil && score.Level != *query.Level) ||
+			(query.Cause != DeathNone && score.DeathCause != query.Cause) {
+			continue
+		}
+
+		index := slices.IndexFunc(hotspots, func(h DeathHotspot) bool {
+			return h.Level == score.Level && h.Cause == score.DeathCause && h.X == score.DeathX && h.Y == score.DeathY
+		})
+		if index < 0 {
+			hotspots = append(hotspots, DeathHotspot{Level: score.Level, Cause: score.DeathCause, X: score.DeathX, Y: score.DeathY})
+			index = len(hotspots) - 1
+		}
+
+		hotspots[index].Deaths++
+	}
+
+	slices.SortFunc(hotspots, func(a, b DeathHotspot) int {
+		return cmp.Or(
+			cmp.Compare(b.Deaths, a.Deaths),
+			cmp.Compare(a.Level, b.Level),
+			cmp.Compare(a.X, b.X),
+			cmp.Compare(a.Y, b.Y),
+		)
+	})
+
+	if query.Limit > 0 && len(hotspots) > query.Limit {
+		hotspots = hotspots[:query.Limit]
+	}
+
+	return hotspots, nil
+}
+
+// GetSessions implements ScoreService. Sessions are only known when the
+// session manager keeps them in memory too.
+func (s *InMemoryScoreService) GetSessions(ctx context.Context) ([]Session, error) {
+	manager, ok := s.Session.(interface{ Sessions() []Session })
+	if !ok {
+		return []Session{}, nil
+	}
+
+	sessions := manager.Sessions()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for index := range sessions {
+		if score := s.find(sessions[index].ID); score != nil {
+			sessions[index].Score = score.Value
+		}
+	}
+
+	return sessions, nil
+}
+
+// GetPersonalBest implements ScoreService.
+func (s *InMemoryScoreService) GetPersonalBest(ctx context.Context) (Score, error) {
+	scores, err := s.QueryScores(ctx, ScoreQuery{Player: s.GetCurrentUser(), Limit: 1})
+	if err != nil || len(scores) == 0 {
+		return Score{}, err
+	}
+
+	return scores[0], nil
+}
+
+// GetHistory implements ScoreService.
+func (s *InMemoryScoreService) GetHistory(ctx context.Context, limit int) ([]Score, error) {
+	scores, err := s.QueryScores(ctx, ScoreQuery{Player: s.GetCurrentUser()})
+	if err != nil {
+		return scores, err
+	}
+
+	// Scores written within the same second keep their insertion order, so
+	// the newest is the one added last.
+	slices.SortStableFunc(scores, func(a, b Score) int {
+		return cmp.Or(b.CreatedAt.Compare(a.CreatedAt), cmp.Compare(b.ID, a.ID))
+	})
+
+	return scores[:min(limit, len(scores))], nil
+}
+
+// SetCurrentUser implements ScoreService.
+func (s *InMemoryScoreService) SetCurrentUser(user string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.CurrentUser = user
+}
+
+// GetCurrentUser implements ScoreService.
+func (s *InMemoryScoreService) GetCurrentUser() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.CurrentUser
+}
+
+func (s *InMemoryScoreService) find(session string) *Score {
+	for _, score := range s.scores {
+		if score.Session == session {
+			return score
+		}
+	}
+
+	return nil
+}
+
+// currentScore returns the score for session, creating it for the current
+// user. A session belonging to another user is left alone, as the SQL upsert
+// does.
+func (s *InMemoryScoreService) currentScore(session string) *Score {
+	score := s.find(session)
+	if score == nil {
+		return s.insert(Score{User: s.CurrentUser, Session: session})
+	}
+
+	if score.User != s.CurrentUser {
+		return nil
+	}
+
+	return score
+}
+
+func (s *InMemoryScoreService) insert(score Score) *Score {
+	s.nextID++
+	score.ID = s.nextID
+	score.Rank = 0
+
+	if score.Mode == "" {
+		score.Mode = GameModeClassic
+	}
+
+	if score.CreatedAt.IsZero() {
+		score.CreatedAt = time.Now().UTC().Truncate(time.Second)
+	}
+
+	s.scores = append(s.scores, &score)
+	return &score
+}
+
+func (s *InMemoryScoreService) saveReplay(session string, replay LevelReplay) {
+	levels := s.replays[session]
+
+	index := slices.IndexFunc(levels, func(l LevelReplay) bool { return l.Level == replay.Level })
+	if index >= 0 {
+		levels[index] = replay
+	} else {
+		levels = append(levels, replay)
+	}
+
+	slices.SortFunc(levels, func(a, b LevelReplay) int { return cmp.Compare(a.Level, b.Level) })
+	s.replays[session] = levels
+}
+
+func (s *InMemoryScoreService) filter(query ScoreQuery) []Score {
+	matching := make([]Score, 0, len(s.scores))
+	for _, score := range s.scores {
+		if (query.Player != "" && score.User != query.Player) ||
+			(query.Level != nil && score.Level != *query.Level) ||
+			(query.Mode != "" && score.Mode != query.Mode) ||
+			(query.Board != "" && score.Board != query.Board) ||
+			(!query.Since.IsZero() && score.CreatedAt.Before(query.Since)) {
+			continue
+		}
+
+		matching = append(matching, *score)
+	}
+
+	return matching
+}
+
+// compareScores orders a before b by column, ascending.
+func compareScores(a, b Score, column ScoreSort) int {
+	switch column {
+	case SortByPlayer:
+		return cmp.Compare(a.DisplayName(), b.DisplayName())
+	case SortByLevel:
+		return cmp.Compare(a.Level, b.Level)
+	case SortByDuration:
+		return cmp.Compare(a.Duration, b.Duration)
+	case SortByDate:
+		return a.CreatedAt.Compare(b.CreatedAt)
+	case SortByVerified:
+		return cmp.Compare(a.Verification, b.Verification)
+	default:
+		return cmp.Compare(a.Value, b.Value)
+	}
+}
@@ -0,0 +1,233 @@
+package internal
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ScoreSort string
+
+const (
+	SortByScore    ScoreSort = "score"
+	SortByPlayer   ScoreSort = "player"
+	SortByLevel    ScoreSort = "level"
+	SortByDuration ScoreSort = "duration"
+	SortByDate     ScoreSort = "date"
+	SortByVerified ScoreSort = "verified"
+)
+
+var scoreSortColumns = map[ScoreSort]string{
+	SortByScore:    "value",
+	SortByPlayer:   `coalesce(nullif(name, ''), "user")`,
+	SortByLevel:    "level",
+	SortByDuration: "duration_ms",
+	SortByDate:     "created_at",
+	SortByVerified: "verification",
+}
+
+// ScoreQuery narrows down the scores returned by ScoreService.QueryScores.
+// Zero values mean "any"; results default to highest score first.
+type ScoreQuery struct {
+	Player    string
+	Level     *int
+	Mode      string
+	Board     string
+	Since     time.Time
+	Limit     int
+	Offset    int
+	SortBy    ScoreSort
+	Ascending bool
+}
+
+// Values encodes the query as URL parameters, the form ParseScoreQuery reads.
+func (q ScoreQuery) Values() url.Values {
+	values := url.Values{}
+
+	if q.Player != "" {
+		values.Set("player", q.Player)
+	}
+
+	if q.Level != nil {
+		values.Set("level", strconv.Itoa(*q.Level))
+	}
+
+	if q.Mode != "" {
+		values.Set("mode", q.Mode)
+	}
+
+	if q.Board != "" {
+		values.Set("board", q.Board)
+	}
+
+	if !q.Since.IsZero() {
+		values.Set("since", q.Since.UTC().Format(time.RFC3339))
+	}
+
+	if q.Limit > 0 {
+		values.Set("limit", strconv.Itoa(q.Limit))
+	}
+
+	if q.Offset > 0 {
+		values.Set("offset", strconv.Itoa(q.Offset))
+	}
+
+	if q.SortBy != "" {
+		values.Set("sort", string(q.SortBy))
+	}
+
+	if q.Ascending {
+		values.Set("order", "asc")
+	}
+
+	return values
+}
+
+// ParseScoreQuery decodes URL parameters written by ScoreQuery.Values.
+func ParseScoreQuery(values url.Values) (ScoreQuery, error) {
+	query := ScoreQuery{
+		Player: values.Get("player"),
+		Mode:   values.Get("mode"),
+		Board:  values.Get("board"),
+		SortBy: ScoreSort(values.Get("sort")),
+	}
+
+	if level := values.Get("level"); level != "" {
+		parsed, err := strconv.Atoi(level)
+		if err != nil {
+			return query, fmt.Errorf("invalid level %q", level)
+		}
+		query.Level = &parsed
+	}
+
+	if since := values.Get("since"); since != "" {
+		parsed, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return query, fmt.Errorf("invalid since %q", since)
+		}
+		query.Since = parsed
+	}
+
+	for name, target := range map[string]*int{"limit": &query.Limit, "offset": &query.Offset} {
+		value := values.Get(name)
+		if value == "" {
+			continue
+		}
+
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			return query, fmt.Errorf("invalid %s %q", name, value)
+		}
+		*target = parsed
+	}
+
+	if _, ok := scoreSortColumns[query.SortBy]; query.SortBy != "" && !ok {
+		return query, fmt.Errorf("invalid sort %q", query.SortBy)
+	}
+
+	switch order := values.Get("order"); order {
+	case "", "desc":
+	case "asc":
+		query.Ascending = true
+	default:
+		return query, fmt.Errorf("invalid order %q", order)
+	}
+
+	return query, nil
+}
+
+func (q ScoreQuery) where() (string, []any) {
+	conditions := make([]string, 0, 5)
+	args := make([]any, 0, 5)
+
+	if q.Player != "" {
+		conditions = append(conditions, `"user" = ?`)
+		args = append(args, q.Player)
+	}
+
+	if q.Level != nil {
+		conditions = append(conditions, "level = ?")
+		args = append(args, *q.Level)
+	}
+
+	if q.Mode != "" {
+		conditions = append(conditions, "mode = ?")
+		args = append(args, q.Mode)
+	}
+
+	if q.Board != "" {
+		conditions = append(conditions, "board = ?")
+		args = append(args, q.Board)
+	}
+
+	if !q.Since.IsZero() {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, q.Since.UTC().Format(sqliteTimeLayout))
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+
+	return ` where ` + strings.Join(conditions, " and "), args
+}
+
+func (q ScoreQuery) orderBy() string {
+	column, ok := scoreSortColumns[q.SortBy]
+	if !ok {
+		column = scoreSortColumns[SortByScore]
+	}
+
+	direction := "desc"
+	if q.Ascending {
+		direction = "asc"
+	}
+
+	return ` order by ` + column + ` ` + direction + `, id asc`
+}
+
+// QueryScores implements ScoreService. Each score's Rank is its position by
+// value among the scores matching the query, regardless of sort order.
+func (s *ScoreServiceImpol) QueryScores(ctx context.Context, query ScoreQuery) ([]Score, error) {
+	scores := make([]Score, 0)
+
+	where, args := query.where()
+	stmt := `select ` + scoreColumns + `, rank() over (order by value desc) from scores` + where + query.orderBy()
+
+	if query.Limit > 0 || query.Offset > 0 {
+		// SQLite reads a negative limit as none, so an offset applies on
+		// its own too.
+		stmt += ` limit ? offset ?`
+		args = append(args, cmp.Or(max(query.Limit, 0), -1), max(query.Offset, 0))
+	}
+
+	rows, err := s.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return scores, storeError("query scores", err)
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var score Score
+		if err := scanScore(rows, &score, &score.Rank); err != nil {
+			return scores, storeError("query scores", err)
+		}
+
+		scores = append(scores, score)
+	}
+
+	return scores, storeError("query scores", rows.Err())
+}
+
+// CountScores implements ScoreService.
+func (s *ScoreServiceImpol) CountScores(ctx context.Context, query ScoreQuery) (int, error) {
+	var count int
+
+	where, args := query.where()
+	err := s.db.QueryRowContext(ctx, `select count(*) from scores`+where, args...).Scan(&count)
+	return count, storeError("count scores", err)
+}
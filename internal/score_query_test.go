@@ -0,0 +1,61 @@
+package internal_test
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/the-Jinxist/golang_snake_game/internal"
+)
+
+func TestScoreQueryValues(t *testing.T) {
+	level := 2
+
+	tests := []struct {
+		name  string
+		query internal.ScoreQuery
+	}{
+		{name: "any score", query: internal.ScoreQuery{}},
+		{name: "level", query: internal.ScoreQuery{Level: &level}},
+		{name: "level 0", query: internal.ScoreQuery{Level: new(int)}},
+		{name: "mode and board", query: internal.ScoreQuery{Mode: internal.GameModeTimeAttack, Board: "30x25"}},
+		{name: "player since", query: internal.ScoreQuery{Player: "alice", Since: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)}},
+		{name: "page", query: internal.ScoreQuery{Limit: 10, Offset: 20, SortBy: internal.SortByDate, Ascending: true}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := internal.ParseScoreQuery(test.query.Values())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, test.query) {
+				t.Fatalf("ParseScoreQuery(%s) = %+v, want %+v", test.query.Values().Encode(), got, test.query)
+			}
+		})
+	}
+}
+
+func TestParseScoreQueryRejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		values url.Values
+	}{
+		{name: "level", values: url.Values{"level": {"one"}}},
+		{name: "since", values: url.Values{"since": {"yesterday"}}},
+		{name: "negative limit", values: url.Values{"limit": {"-1"}}},
+		{name: "negative offset", values: url.Values{"offset": {"-1"}}},
+		{name: "sort", values: url.Values{"sort": {"food"}}},
+		{name: "order", values: url.Values{"order": {"up"}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if query, err := internal.ParseScoreQuery(test.values); err == nil {
+				t.Fatalf("ParseScoreQuery(%s) = %+v, want an error", test.values.Encode(), query)
+			}
+		})
+	}
+}
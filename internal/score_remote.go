@@ -0,0 +1,405 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// leaderboardQueueFileName holds the submissions still waiting for one
+	// server; it is named after the server's URL so switching servers never
+	// sends one server's scores to another.
+	leaderboardQueueFileName = "leaderboard_queue_%s.json"
+	remoteRequestTimeout     = 3 * time.Second
+	// remotePageSize is the most scores asked for in one request; it
+	// matches the most a leaderboard server returns.
+	remotePageSize = 100
+)
+
+var _ ScoreService = &RemoteScoreService{}
+
+// ErrScoreRejected is returned when the leaderboard server refuses a
+// submission outright; retrying it would not help.
+var ErrScoreRejected = errors.New("score rejected by leaderboard server")
+
+// RemoteScoreService shares scores through a leaderboard server. Every write
+// goes to the local ScoreService first, so sessions, stats and personal
+// history keep working offline; finished writes are then pushed to the
+// server. Submissions that fail are queued on disk and retried with the next
+// one. Board reads come from the server and fall back to the local database
+// when it cannot be reached.
+type RemoteScoreService struct {
+	ScoreService
+
+	baseURL   string
+	client    *http.Client
+	queuePath string
+	mu        sync.Mutex
+}
+
+// NewRemoteScoreService returns a ScoreService talking to the leaderboard
+// server at baseURL, backed by local.
+func NewRemoteScoreService(baseURL string, local ScoreService) (*RemoteScoreService, error) {
+	parsed, err := url.Parse(baseURL)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid leaderboard url %q", baseURL)
+	}
+
+	dir, err := DataDir()
+	if err != nil {
+		return nil, err
+	}
+
+	return &RemoteScoreService{
+		ScoreService: local,
+		baseURL:      strings.TrimRight(baseURL, "/"),
+		client:       &http.Client{Timeout: remoteRequestTimeout},
+		queuePath:    filepath.Join(dir, queueFileName(baseURL)),
+	}, nil
+}
+
+// queueFileName is the name of the submission queue for the server at
+// baseURL.
+func queueFileName(baseURL string) string {
+	sum := sha256.Sum256([]byte(strings.TrimRight(baseURL, "/")))
+	return fmt.Sprintf(leaderboardQueueFileName, hex.EncodeToString(sum[:8]))
+}
+
+// GetHighScore implements ScoreService.
+func (r *RemoteScoreService) GetHighScore(ctx context.Context) (Score, error) {
+	scores, err := r.QueryScores(ctx, ScoreQuery{Limit: 1})
+	if err != nil || len(scores) == 0 {
+		return r.ScoreService.GetHighScore(ctx)
+	}
+
+	return scores[0], nil
+}
+
+// GetScores implements ScoreService.
+func (r *RemoteScoreService) GetScores(ctx context.Context) ([]Score, error) {
+	return r.QueryScores(ctx, ScoreQuery{Limit: 5})
+}
+
+// QueryScores implements ScoreService. The server returns at most a page of
+// scores per request, so larger queries, and queries without a limit, are
+// read a page at a time.
+func (r *RemoteScoreService) QueryScores(ctx context.Context, query ScoreQuery) ([]Score, error) {
+	if query.Limit > 0 && query.Limit <= remotePageSize {
+		scores, err := r.queryPage(ctx, query)
+		if err != nil {
+			return r.ScoreService.QueryScores(ctx, query)
+		}
+
+		return scores, nil
+	}
+
+	total, err := r.countRemote(ctx, query)
+	if err != nil {
+		return r.ScoreService.QueryScores(ctx, query)
+	}
+
+	want := max(total-query.Offset, 0)
+	if query.Limit > 0 {
+		want = min(want, query.Limit)
+	}
+
+	scores := make([]Score, 0, want)
+	for len(scores) < want {
+		page := query
+		page.Offset = query.Offset + len(scores)
+		page.Limit = min(remotePageSize, want-len(scores))
+
+		records, err := r.queryPage(ctx, page)
+		if err != nil {
+			return nil, fmt.Errorf("leaderboard server: read %d of %d scores: %w", len(scores), want, err)
+		}
+
+		// A server that stops short would otherwise leave the caller with
+		// part of the board and no sign anything is missing.
+		if len(records) == 0 {
+			return nil, fmt.Errorf("leaderboard server: returned %d of %d scores", len(scores), want)
+		}
+
+		scores = append(scores, records...)
+	}
+
+	return scores, nil
+}
+
+func (r *RemoteScoreService) queryPage(ctx context.Context, query ScoreQuery) ([]Score, error) {
+	var records []ScoreRecord
+	if err := r.get(ctx, "/api/scores", query.Values(), &records); err != nil {
+		return nil, err
+	}
+
+	scores := make([]Score, 0, len(records))
+	for _, record := range records {
+		scores = append(scores, record.Score())
+	}
+
+	return scores, nil
+}
+
+// CountScores implements ScoreService.
+func (r *RemoteScoreService) CountScores(ctx context.Context, query ScoreQuery) (int, error) {
+	count, err := r.countRemote(ctx, query)
+	if err != nil {
+		return r.ScoreService.CountScores(ctx, query)
+	}
+
+	return count, nil
+}
+
+func (r *RemoteScoreService) countRemote(ctx context.Context, query ScoreQuery) (int, error) {
+	var body struct {
+		Count int `json:"count"`
+	}
+
+	err := r.get(ctx, "/api/scores/count", query.Values(), &body)
+	return body.Count, err
+}
+
+// ImportScores implements ScoreService. Scores are only imported into the
+// local copy: an export carries no replays, and the server only ranks runs
+// it has replayed.
+func (r *RemoteScoreService) ImportScores(ctx context.Context, scores []Score) (int, error) {
+	return r.ScoreService.ImportScores(ctx, scores)
+}
+
+// SubmitScore implements ScoreService. The score is kept locally as well,
+// and queued when the server cannot take it right now.
+func (r *RemoteScoreService) SubmitScore(ctx context.Context, score Score, replay []LevelReplay) error {
+	if err := r.ScoreService.SubmitScore(ctx, score, replay); err != nil {
+		return err
+	}
+
+	return r.submit(ctx, score, replay)
+}
+
+// submit sends score to the server, after anything still queued from
+// earlier. Only a rejection of score itself is returned.
+func (r *RemoteScoreService) submit(ctx context.Context, score Score, replay []LevelReplay) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	queue, err := r.loadQueue()
+	if err != nil {
+		return err
+	}
+
+	record := NewScoreRecord(score)
+	record.Replay = replay
+	queue = append(removeQueued(queue, score.Session), record)
+
+	var rejected error
+	for len(queue) > 0 {
+		err := r.postScore(ctx, queue[0])
+		if err != nil && !errors.Is(err, ErrScoreRejected) {
+			break
+		}
+
+		if err != nil && queue[0].Session == score.Session {
+			rejected = err
+		}
+		queue = queue[1:]
+	}
+
+	if err := r.saveQueue(queue); err != nil {
+		return err
+	}
+
+	return rejected
+}
+
+// SetCurrentScore implements ScoreService.
+func (r *RemoteScoreService) SetCurrentScore(ctx context.Context, value int, played time.Duration) error {
+	if err := r.ScoreService.SetCurrentScore(ctx, value, played); err != nil {
+		return err
+	}
+
+	return r.submitCurrent(ctx)
+}
+
+// SetCurrentScoreName implements ScoreService.
+func (r *RemoteScoreService) SetCurrentScoreName(ctx context.Context, name string) error {
+	if err := r.ScoreService.SetCurrentScoreName(ctx, name); err != nil {
+		return err
+	}
+
+	return r.submitCurrent(ctx)
+}
+
+// SetCurrentDeath implements ScoreService.
+func (r *RemoteScoreService) SetCurrentDeath(ctx context.Context, cause DeathCause, x, y int) error {
+	if err := r.ScoreService.SetCurrentDeath(ctx, cause, x, y); err != nil {
+		return err
+	}
+
+	return r.submitCurrent(ctx)
+}
+
+// PendingSubmissions returns the number of scores waiting for the server.
+func (r *RemoteScoreService) PendingSubmissions() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	queue, _ := r.loadQueue()
+	return len(queue)
+}
+
+// submitCurrent pushes the local copy of the current session's score along
+// with its replay, so the server can check it.
+func (r *RemoteScoreService) submitCurrent(ctx context.Context) error {
+	score, err := r.ScoreService.GetCurrentScore(ctx)
+	if err != nil {
+		return err
+	}
+
+	if score.Session == "" {
+		return nil
+	}
+
+	replay, err := r.ScoreService.GetReplay(ctx, score.Session)
+	if err != nil {
+		return err
+	}
+
+	return r.submit(ctx, score, replay)
+}
+
+func (r *RemoteScoreService) get(ctx context.Context, path string, values url.Values, target any) error {
+	endpoint := r.baseURL + path
+	if len(values) > 0 {
+		endpoint += "?" + values.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	return r.do(req, target)
+}
+
+// postScore submits record. A server refusing it outright is reported as
+// ErrScoreRejected, as sending it again would not help; being told to slow
+// down or try again later is not a refusal.
+func (r *RemoteScoreService) postScore(ctx context.Context, record ScoreRecord) error {
+	err := r.post(ctx, "/api/scores", record)
+
+	var status *statusError
+	if errors.As(err, &status) && status.code < http.StatusInternalServerError &&
+		status.code != http.StatusRequestTimeout && status.code != http.StatusTooManyRequests {
+		return fmt.Errorf("%w: %s", ErrScoreRejected, status.message)
+	}
+
+	return err
+}
+
+func (r *RemoteScoreService) post(ctx context.Context, path string, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.baseURL+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	return r.do(req, nil)
+}
+
+func (r *RemoteScoreService) do(req *http.Request, target any) error {
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		var body struct {
+			Error string `json:"error"`
+		}
+		json.NewDecoder(resp.Body).Decode(&body)
+
+		return &statusError{code: resp.StatusCode, status: resp.Status, message: body.Error}
+	}
+
+	if target == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(target)
+}
+
+// statusError is a response the leaderboard server answered with an error
+// status.
+type statusError struct {
+	code    int
+	status  string
+	message string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("leaderboard server: %s: %s", e.status, e.message)
+}
+
+func (r *RemoteScoreService) loadQueue() ([]ScoreRecord, error) {
+	var queue []ScoreRecord
+
+	data, err := os.ReadFile(r.queuePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return queue, nil
+	}
+	if err != nil {
+		return queue, err
+	}
+
+	err = json.Unmarshal(data, &queue)
+	return queue, err
+}
+
+func (r *RemoteScoreService) saveQueue(queue []ScoreRecord) error {
+	if len(queue) == 0 {
+		err := os.Remove(r.queuePath)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	data, err := json.Marshal(queue)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.queuePath, data, 0o644)
+}
+
+// removeQueued drops an older submission for session; only the latest state
+// of a run needs to reach the server.
+func removeQueued(queue []ScoreRecord, session string) []ScoreRecord {
+	kept := queue[:0]
+	for _, record := range queue {
+		if record.Session != session {
+			kept = append(kept, record)
+		}
+	}
+
+	return kept
+}
@@ -0,0 +1,116 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/the-Jinxist/golang_snake_game/internal"
+)
+
+// stubServer answers every submission with submitStatus and every board
+// read with readStatus, claiming a board too big for one page.
+func stubServer(t *testing.T, submitStatus, readStatus int) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/scores", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(submitStatus)
+	})
+	mux.HandleFunc("GET /api/scores", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(readStatus)
+	})
+	mux.HandleFunc("GET /api/scores/count", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"count": 250}`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func remoteStore(t *testing.T, url string) *internal.RemoteScoreService {
+	t.Helper()
+
+	sessions := internal.NewSessionManager()
+	sessions.SetUser("alice")
+
+	scores, err := internal.NewRemoteScoreService(url, internal.NewInMemoryScoreService("alice", sessions))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return scores
+}
+
+func TestRemoteSubmission(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		wantRejected bool
+		// wantPending is how many submissions are left queued.
+		wantPending int
+	}{
+		{name: "accepted", status: http.StatusNoContent},
+		{name: "bad replay", status: http.StatusUnprocessableEntity, wantRejected: true},
+		{name: "bad request", status: http.StatusBadRequest, wantRejected: true},
+		{name: "slow down", status: http.StatusTooManyRequests, wantPending: 1},
+		{name: "timed out", status: http.StatusRequestTimeout, wantPending: 1},
+		{name: "server error", status: http.StatusServiceUnavailable, wantPending: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("XDG_DATA_HOME", t.TempDir())
+			scores := remoteStore(t, stubServer(t, test.status, http.StatusOK).URL)
+
+			err := scores.SubmitScore(context.Background(), internal.Score{User: "alice", Session: "a", Value: 10}, nil)
+			if rejected := errors.Is(err, internal.ErrScoreRejected); rejected != test.wantRejected {
+				t.Fatalf("SubmitScore() = %v, want rejected: %t", err, test.wantRejected)
+			}
+
+			if !test.wantRejected && err != nil {
+				t.Fatal(err)
+			}
+
+			if pending := scores.PendingSubmissions(); pending != test.wantPending {
+				t.Fatalf("%d submissions pending, want %d", pending, test.wantPending)
+			}
+		})
+	}
+}
+
+func TestRemoteReadIsNotARejection(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	scores := remoteStore(t, stubServer(t, http.StatusNoContent, http.StatusBadRequest).URL)
+
+	_, err := scores.QueryScores(context.Background(), internal.ScoreQuery{})
+	if err == nil {
+		t.Fatal("QueryScores() = nil, want the failed read reported")
+	}
+
+	if errors.Is(err, internal.ErrScoreRejected) {
+		t.Fatalf("QueryScores() = %v, a failed read is not a rejected score", err)
+	}
+}
+
+func TestRemoteQueuePerServer(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+
+	down := stubServer(t, http.StatusServiceUnavailable, http.StatusOK)
+	up := stubServer(t, http.StatusNoContent, http.StatusOK)
+
+	if err := remoteStore(t, down.URL).SubmitScore(context.Background(), internal.Score{User: "alice", Session: "a", Value: 10}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if pending := remoteStore(t, down.URL+"/").PendingSubmissions(); pending != 1 {
+		t.Fatalf("%d submissions queued for the server that was down, want 1", pending)
+	}
+
+	if pending := remoteStore(t, up.URL).PendingSubmissions(); pending != 0 {
+		t.Fatalf("%d submissions queued for another server, want none", pending)
+	}
+}
@@ -0,0 +1,96 @@
+package internal
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ScoreUpdate is a snapshot of the current session's score, written as one
+// unit so the score and the replay that produced it never drift apart.
+type ScoreUpdate struct {
+	Value  int
+	Played time.Duration
+	Replay LevelReplay
+}
+
+// ScoreWriter writes score updates in the background, one at a time and in
+// the order they were made. While a write is in flight only the newest
+// pending update is kept: each update is a full snapshot, so the ones in
+// between would be overwritten anyway.
+type ScoreWriter struct {
+	scores ScoreService
+
+	mu      sync.Mutex
+	idle    *sync.Cond
+	pending *ScoreUpdate
+	running bool
+	err     error
+}
+
+func NewScoreWriter(scores ScoreService) *ScoreWriter {
+	w := &ScoreWriter{scores: scores}
+	w.idle = sync.NewCond(&w.mu)
+	return w
+}
+
+// Write queues update, replacing any update that has not been written yet.
+func (w *ScoreWriter) Write(update ScoreUpdate) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.pending = &update
+	if !w.running {
+		w.running = true
+		go w.run()
+	}
+}
+
+// Flush blocks until every queued update has been written and returns the
+// error of the last write, if it failed.
+func (w *ScoreWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for w.running {
+		w.idle.Wait()
+	}
+
+	return w.err
+}
+
+// Err returns the error of the last write, or nil once a write succeeds.
+func (w *ScoreWriter) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.err
+}
+
+func (w *ScoreWriter) run() {
+	w.mu.Lock()
+	for w.pending != nil {
+		update := *w.pending
+		w.pending = nil
+		w.mu.Unlock()
+
+		err := w.write(update)
+
+		w.mu.Lock()
+		w.err = err
+	}
+
+	w.running = false
+	w.idle.Broadcast()
+	w.mu.Unlock()
+}
+
+func (w *ScoreWriter) write(update ScoreUpdate) error {
+	ctx := context.Background()
+
+	if err := w.scores.SetCurrentReplay(ctx, update.Replay); err != nil {
+		return err
+	}
+
+	return w.scores.SetCurrentScore(ctx, update.Value, update.Played)
+}
@@ -0,0 +1,143 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/the-Jinxist/golang_snake_game/internal"
+)
+
+// gatedScores records the values written through it. The first write waits
+// for release, so the test can queue more behind it.
+type gatedScores struct {
+	*internal.InMemoryScoreService
+
+	started chan struct{}
+	release chan struct{}
+	fail    error
+
+	mu      sync.Mutex
+	written []int
+}
+
+func newGatedScores() *gatedScores {
+	sessions := internal.NewSessionManager()
+	return &gatedScores{
+		InMemoryScoreService: internal.NewInMemoryScoreService("alice", sessions),
+		started:              make(chan struct{}),
+		release:              make(chan struct{}),
+	}
+}
+
+func (g *gatedScores) SetCurrentScore(ctx context.Context, value int, played time.Duration) error {
+	g.mu.Lock()
+	first := len(g.written) == 0
+	g.written = append(g.written, value)
+	g.mu.Unlock()
+
+	if first {
+		close(g.started)
+		<-g.release
+	}
+
+	if g.fail != nil {
+		return g.fail
+	}
+
+	return g.InMemoryScoreService.SetCurrentScore(ctx, value, played)
+}
+
+func TestScoreWriter(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []int
+		// want is what reaches the store: the first write, then only the
+		// newest of the ones queued behind it.
+		want []int
+	}{
+		{name: "single write", values: []int{10}, want: []int{10}},
+		{name: "one queued behind", values: []int{10, 20}, want: []int{10, 20}},
+		{name: "burst coalesces to the newest", values: []int{10, 20, 30, 40}, want: []int{10, 40}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scores := newGatedScores()
+			writer := internal.NewScoreWriter(scores)
+
+			writer.Write(update(test.values[0]))
+			<-scores.started
+
+			for _, value := range test.values[1:] {
+				writer.Write(update(value))
+			}
+
+			close(scores.release)
+			if err := writer.Flush(); err != nil {
+				t.Fatalf("Flush() = %v", err)
+			}
+
+			if !slices.Equal(scores.written, test.want) {
+				t.Fatalf("written %v, want %v", scores.written, test.want)
+			}
+
+			last := test.values[len(test.values)-1]
+			current, err := scores.GetCurrentScore(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if current.Value != last {
+				t.Fatalf("current score = %d, want %d", current.Value, last)
+			}
+
+			session, _ := scores.Session.GetCurrentSession()
+			replay, err := scores.GetReplay(context.Background(), session)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(replay) != 1 || replay[0].Ticks != last {
+				t.Fatalf("replay = %+v, want the one written with %d", replay, last)
+			}
+		})
+	}
+}
+
+func TestScoreWriterError(t *testing.T) {
+	scores := newGatedScores()
+	scores.fail = errors.New("disk full")
+	close(scores.release)
+
+	writer := internal.NewScoreWriter(scores)
+	writer.Write(update(10))
+
+	if err := writer.Flush(); !errors.Is(err, scores.fail) {
+		t.Fatalf("Flush() = %v, want %v", err, scores.fail)
+	}
+
+	if err := writer.Err(); !errors.Is(err, scores.fail) {
+		t.Fatalf("Err() = %v, want %v", err, scores.fail)
+	}
+
+	scores.fail = nil
+	writer.Write(update(20))
+
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush() after a successful write = %v, want nil", err)
+	}
+}
+
+// update is a score update whose replay records value as its move count, so
+// the test can tell which update a stored replay came from.
+func update(value int) internal.ScoreUpdate {
+	return internal.ScoreUpdate{
+		Value:  value,
+		Played: time.Second,
+		Replay: internal.LevelReplay{Ticks: value},
+	}
+}
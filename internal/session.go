@@ -3,36 +3,81 @@ package internal
 import (
 	"crypto/rand"
 	"encoding/base64"
+	"sync"
+	"time"
 )
 
 var _ SessionManager = &InMemeorySessiomManagerImpl{}
 
+type SessionState string
+
+const (
+	SessionActive    SessionState = "active"
+	SessionSuspended SessionState = "suspended"
+	SessionFinished  SessionState = "finished"
+	SessionAbandoned SessionState = "abandoned"
+)
+
+type Session struct {
+	ID        string       `db:"id" json:"id"`
+	User      string       `db:"user" json:"user"`
+	Level     int          `db:"level" json:"level"`
+	Seed      uint64       `db:"seed" json:"seed"`
+	State     SessionState `db:"state" json:"state"`
+	Score     int          `db:"score" json:"-"`
+	StartedAt time.Time    `db:"started_at" json:"started_at"`
+	EndedAt   time.Time    `db:"ended_at" json:"ended_at"`
+}
+
 type SessionManager interface {
 	CreateNewSession(value any) (string, error)
 	DestroyCurrentSession() error
 	GetCurrentSession() (string, error)
+	RestoreSession(session string) error
+	UpdateCurrentSession(level int, seed uint64) error
+	SuspendCurrentSession() error
+	FinishCurrentSession() error
+	SetUser(user string)
 }
 
 func NewSessionManager() SessionManager {
 	return &InMemeorySessiomManagerImpl{}
 }
 
+// InMemeorySessiomManagerImpl keeps sessions in memory only. It follows the
+// same lifecycle as SQLiteSessionManager, for guest play and tests.
 type InMemeorySessiomManagerImpl struct {
-	session string
+	mu       sync.Mutex
+	user     string
+	session  string
+	sessions []*Session
 }
 
 // CreateNewSession implements SessionManager.
 func (i *InMemeorySessiomManagerImpl) CreateNewSession(value any) (string, error) {
-	session, err := i.randomString(20)
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return i.createNewSession()
+}
+
+func (i *InMemeorySessiomManagerImpl) createNewSession() (string, error) {
+	session, err := randomSessionID(20)
 	if err != nil {
 		return session, err
 	}
 
 	i.session = session
+	i.sessions = append(i.sessions, &Session{
+		ID:        session,
+		User:      i.user,
+		State:     SessionActive,
+		StartedAt: time.Now(),
+	})
 	return session, nil
 }
 
-func (i *InMemeorySessiomManagerImpl) randomString(n int) (string, error) {
+func randomSessionID(n int) (string, error) {
 	b := make([]byte, n)
 	if _, err := rand.Read(b); err != nil {
 		return "", err
@@ -43,15 +88,121 @@ func (i *InMemeorySessiomManagerImpl) randomString(n int) (string, error) {
 
 // DestroyCurrentSession implements SessionManager.
 func (i *InMemeorySessiomManagerImpl) DestroyCurrentSession() error {
-	i.session = ""
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.end(SessionAbandoned)
 	return nil
 }
 
 // GetCurrentSession implements SessionManager.
 func (i *InMemeorySessiomManagerImpl) GetCurrentSession() (string, error) {
+	session, _, err := i.getCurrentSession()
+	return session, err
+}
+
+// getCurrentSession returns the current session, starting one if there is
+// none, and whether it did.
+func (i *InMemeorySessiomManagerImpl) getCurrentSession() (string, bool, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.session != "" {
+		return i.session, false, nil
+	}
+
+	session, err := i.createNewSession()
+	return session, err == nil, err
+}
+
+// RestoreSession implements SessionManager.
+func (i *InMemeorySessiomManagerImpl) RestoreSession(session string) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.session = session
+	if current := i.current(); current != nil {
+		current.State = SessionActive
+		current.EndedAt = time.Time{}
+	}
+	return nil
+}
+
+// UpdateCurrentSession implements SessionManager.
+func (i *InMemeorySessiomManagerImpl) UpdateCurrentSession(level int, seed uint64) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
 	if i.session == "" {
-		return i.CreateNewSession(nil)
+		if _, err := i.createNewSession(); err != nil {
+			return err
+		}
+	}
+
+	current := i.current()
+	current.Level = level
+	current.Seed = seed
+	return nil
+}
+
+// SuspendCurrentSession implements SessionManager.
+func (i *InMemeorySessiomManagerImpl) SuspendCurrentSession() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if current := i.current(); current != nil {
+		current.State = SessionSuspended
+	}
+	return nil
+}
+
+// FinishCurrentSession implements SessionManager.
+func (i *InMemeorySessiomManagerImpl) FinishCurrentSession() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.end(SessionFinished)
+	return nil
+}
+
+// SetUser implements SessionManager. It applies to sessions created from now on.
+func (i *InMemeorySessiomManagerImpl) SetUser(user string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.user = user
+}
+
+// Sessions returns every session created so far, newest first.
+func (i *InMemeorySessiomManagerImpl) Sessions() []Session {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	sessions := make([]Session, 0, len(i.sessions))
+	for index := len(i.sessions) - 1; index >= 0; index-- {
+		sessions = append(sessions, *i.sessions[index])
+	}
+
+	return sessions
+}
+
+func (i *InMemeorySessiomManagerImpl) current() *Session {
+	for _, session := range i.sessions {
+		if session.ID == i.session {
+			return session
+		}
 	}
 
-	return i.session, nil
+	return nil
+}
+
+// end moves the current session to state unless it already reached another
+// one, and clears it.
+func (i *InMemeorySessiomManagerImpl) end(state SessionState) {
+	if current := i.current(); current != nil && current.State == SessionActive {
+		current.State = state
+		current.EndedAt = time.Now()
+	}
+
+	i.session = ""
 }
@@ -0,0 +1,183 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var _ SessionManager = &SQLiteSessionManager{}
+
+// SQLiteSessionManager keeps the active session in the sessions table so a
+// crash mid-run leaves a record behind instead of an orphaned score row.
+type SQLiteSessionManager struct {
+	db *sql.DB
+
+	// mu guards user and session, which the score writer and commands
+	// use from their own goroutines.
+	mu      sync.Mutex
+	user    string
+	session string
+}
+
+// staleSessionAge is how long an active session can go without a score
+// being recorded before it is taken to belong to a run that crashed.
+const staleSessionAge = 30 * time.Minute
+
+// NewSQLiteSessionManager returns a session manager backed by db. The user's
+// sessions left active by a run that crashed are marked abandoned; sessions
+// still being played by another process are left alone.
+func NewSQLiteSessionManager(user string, db *sql.DB) (SessionManager, error) {
+	err := abandonStaleSessions(db, user)
+	if err != nil {
+		return nil, storeError("abandon stale sessions", err)
+	}
+
+	return &SQLiteSessionManager{
+		db:   db,
+		user: user,
+	}, nil
+}
+
+// abandonStaleSessions marks user's active sessions abandoned when nothing
+// has been recorded for them within staleSessionAge.
+func abandonStaleSessions(db *sql.DB, user string) error {
+	_, err := db.Exec(`
+	update sessions set state = ?, ended_at = current_timestamp
+	where state = ? and "user" = ?
+		and coalesce((select max(updated_at) from replays where replays.session = sessions.id), started_at) < datetime('now', ?)`,
+		SessionAbandoned, SessionActive, user, fmt.Sprintf("-%d seconds", int(staleSessionAge.Seconds())),
+	)
+	return err
+}
+
+// CreateNewSession implements SessionManager.
+func (s *SQLiteSessionManager) CreateNewSession(value any) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.createNewSession()
+}
+
+func (s *SQLiteSessionManager) createNewSession() (string, error) {
+	session, err := randomSessionID(20)
+	if err != nil {
+		return session, storeError("create session", err)
+	}
+
+	_, err = s.db.ExecContext(context.Background(),
+		`insert into sessions (id, "user", state) values (?, ?, ?)`,
+		session, s.user, SessionActive,
+	)
+	if err != nil {
+		return "", storeError("create session", err)
+	}
+
+	s.session = session
+	return session, nil
+}
+
+// DestroyCurrentSession implements SessionManager. The session is marked
+// abandoned unless it already reached another state.
+func (s *SQLiteSessionManager) DestroyCurrentSession() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.session == "" {
+		return nil
+	}
+
+	err := s.setState(SessionAbandoned, true)
+	s.session = ""
+	return storeError("abandon session", err)
+}
+
+// GetCurrentSession implements SessionManager.
+func (s *SQLiteSessionManager) GetCurrentSession() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.session == "" {
+		return s.createNewSession()
+	}
+
+	return s.session, nil
+}
+
+// RestoreSession implements SessionManager.
+func (s *SQLiteSessionManager) RestoreSession(session string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.session = session
+	return storeError("restore session", s.setState(SessionActive, false))
+}
+
+// UpdateCurrentSession implements SessionManager.
+func (s *SQLiteSessionManager) UpdateCurrentSession(level int, seed uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.session == "" {
+		if _, err := s.createNewSession(); err != nil {
+			return err
+		}
+	}
+
+	_, err := s.db.ExecContext(context.Background(),
+		`update sessions set level = ?, seed = ? where id = ?`,
+		level, int64(seed), s.session,
+	)
+	return storeError("update session", err)
+}
+
+// SuspendCurrentSession implements SessionManager.
+func (s *SQLiteSessionManager) SuspendCurrentSession() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.session == "" {
+		return nil
+	}
+
+	return storeError("suspend session", s.setState(SessionSuspended, false))
+}
+
+// FinishCurrentSession implements SessionManager.
+func (s *SQLiteSessionManager) FinishCurrentSession() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.session == "" {
+		return nil
+	}
+
+	err := s.setState(SessionFinished, true)
+	s.session = ""
+	return storeError("finish session", err)
+}
+
+// SetUser implements SessionManager. It applies to sessions created from now
+// on. The new user's crashed sessions are tidied up on a best-effort basis.
+func (s *SQLiteSessionManager) SetUser(user string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if user != s.user {
+		abandonStaleSessions(s.db, user)
+	}
+
+	s.user = user
+}
+
+func (s *SQLiteSessionManager) setState(state SessionState, ended bool) error {
+	query := `update sessions set state = ?, ended_at = null where id = ?`
+	if ended {
+		query = `update sessions set state = ?, ended_at = current_timestamp where id = ? and state = 'active'`
+	}
+
+	_, err := s.db.ExecContext(context.Background(), query, state, s.session)
+	return err
+}
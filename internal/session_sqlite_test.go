@@ -0,0 +1,172 @@
+package internal_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/the-Jinxist/golang_snake_game/internal"
+)
+
+func openDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := internal.CreateDB(filepath.Join(t.TempDir(), "scores.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func sessionState(t *testing.T, db *sql.DB, id string) internal.SessionState {
+	t.Helper()
+
+	var state internal.SessionState
+	if err := db.QueryRow(`select state from sessions where id = ?`, id).Scan(&state); err != nil {
+		t.Fatal(err)
+	}
+
+	return state
+}
+
+func TestSQLiteSessionLifecycle(t *testing.T) {
+	tests := []struct {
+		name string
+		end  func(sessions internal.SessionManager) error
+		want internal.SessionState
+	}{
+		{name: "finished", end: internal.SessionManager.FinishCurrentSession, want: internal.SessionFinished},
+		{name: "abandoned", end: internal.SessionManager.DestroyCurrentSession, want: internal.SessionAbandoned},
+		{name: "suspended", end: internal.SessionManager.SuspendCurrentSession, want: internal.SessionSuspended},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db := openDB(t)
+			sessions, err := internal.NewSQLiteSessionManager("alice", db)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := sessions.UpdateCurrentSession(3, 9); err != nil {
+				t.Fatal(err)
+			}
+
+			id, err := sessions.GetCurrentSession()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := test.end(sessions); err != nil {
+				t.Fatal(err)
+			}
+
+			var (
+				user        string
+				level, seed int
+			)
+
+			if err := db.QueryRow(`select "user", level, seed from sessions where id = ?`, id).Scan(&user, &level, &seed); err != nil {
+				t.Fatal(err)
+			}
+
+			if state := sessionState(t, db, id); state != test.want || user != "alice" || level != 3 || seed != 9 {
+				t.Fatalf("session = %s/%s/%d/%d, want alice's level 3 run with seed 9 %s", user, state, level, seed, test.want)
+			}
+		})
+	}
+}
+
+func TestSQLiteSessionAbandonsOnlyStaleSessions(t *testing.T) {
+	db := openDB(t)
+
+	_, err := db.Exec(`insert into sessions (id, "user", state, started_at) values
+		('crashed', 'alice', 'active', datetime('now', '-2 hours')),
+		('playing', 'alice', 'active', datetime('now')),
+		('saved', 'alice', 'suspended', datetime('now', '-2 hours')),
+		('other', 'bob', 'active', datetime('now', '-2 hours'))`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := internal.NewSQLiteSessionManager("alice", db); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]internal.SessionState{
+		"crashed": internal.SessionAbandoned,
+		"playing": internal.SessionActive,
+		"saved":   internal.SessionSuspended,
+		"other":   internal.SessionActive,
+	}
+
+	for id, state := range want {
+		if got := sessionState(t, db, id); got != state {
+			t.Fatalf("session %s is %s, want %s", id, got, state)
+		}
+	}
+}
+
+func TestSQLiteSessionReadsStartNoSession(t *testing.T) {
+	db := openDB(t)
+	sessions, err := internal.NewSQLiteSessionManager("alice", db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scores := internal.NewScoreService("alice", sessions, db)
+	ctx := context.Background()
+
+	if _, err := scores.GetHighScore(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := scores.GetPersonalBest(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := scores.QueryScores(ctx, internal.ScoreQuery{}); err != nil {
+		t.Fatal(err)
+	}
+
+	var count int
+	if err := db.QueryRow(`select count(*) from sessions`).Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 0 {
+		t.Fatalf("reading scores created %d sessions, want none", count)
+	}
+}
+
+func TestSQLiteSessionConcurrentUse(t *testing.T) {
+	db := openDB(t)
+	sessions, err := internal.NewSQLiteSessionManager("alice", db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sessions.GetCurrentSession()
+		}()
+	}
+
+	wg.Wait()
+
+	var count int
+	if err := db.QueryRow(`select count(*) from sessions`).Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 1 {
+		t.Fatalf("concurrent callers created %d sessions, want 1", count)
+	}
+}
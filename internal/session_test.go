@@ -0,0 +1,113 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/the-Jinxist/golang_snake_game/internal"
+)
+
+func TestInMemorySessionLifecycle(t *testing.T) {
+	tests := []struct {
+		name string
+		// end moves the session on from active.
+		end  func(sessions internal.SessionManager) error
+		want internal.SessionState
+	}{
+		{name: "finished", end: internal.SessionManager.FinishCurrentSession, want: internal.SessionFinished},
+		{name: "abandoned", end: internal.SessionManager.DestroyCurrentSession, want: internal.SessionAbandoned},
+		{name: "suspended", end: internal.SessionManager.SuspendCurrentSession, want: internal.SessionSuspended},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sessions := &internal.InMemeorySessiomManagerImpl{}
+			sessions.SetUser("alice")
+
+			if err := sessions.UpdateCurrentSession(2, 7); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := test.end(sessions); err != nil {
+				t.Fatal(err)
+			}
+
+			played := sessions.Sessions()
+			if len(played) != 1 {
+				t.Fatalf("%d sessions, want 1", len(played))
+			}
+
+			session := played[0]
+			if session.State != test.want || session.User != "alice" || session.Level != 2 || session.Seed != 7 {
+				t.Fatalf("session = %+v, want alice's level 2 run with seed 7 %s", session, test.want)
+			}
+		})
+	}
+}
+
+func TestInMemorySessionEndsOnce(t *testing.T) {
+	sessions := &internal.InMemeorySessiomManagerImpl{}
+
+	first, err := sessions.GetCurrentSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sessions.FinishCurrentSession()
+	sessions.DestroyCurrentSession()
+
+	second, err := sessions.GetCurrentSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if second == first {
+		t.Fatal("a finished session was handed out again")
+	}
+
+	played := sessions.Sessions()
+	if len(played) != 2 || played[1].State != internal.SessionFinished {
+		t.Fatalf("sessions = %+v, want the first still finished", played)
+	}
+}
+
+func TestGuestApp(t *testing.T) {
+	tests := []struct {
+		name       string
+		options    internal.Options
+		wantPlayer string
+		wantErr    bool
+	}{
+		{name: "default name", options: internal.Options{Guest: true}, wantPlayer: "guest"},
+		{name: "named guest", options: internal.Options{Guest: true, Player: "alice"}, wantPlayer: "alice"},
+		{name: "leaderboard server", options: internal.Options{Guest: true, LeaderboardURL: "http://localhost:8080"}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+			app, err := internal.NewApp(test.options)
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("NewApp() = nil, want an error")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer app.Close()
+
+			if !app.Guest || app.DB != nil || app.StorePath != "" {
+				t.Fatalf("app = guest %t, db %v, store %q; want a guest app with nothing on disk", app.Guest, app.DB, app.StorePath)
+			}
+
+			if got := app.Scores.GetCurrentUser(); got != test.wantPlayer {
+				t.Fatalf("player = %q, want %q", got, test.wantPlayer)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/the-Jinxist/golang_snake_game/utils"
+)
+
+const settingsFileName = "settings.json"
+
+// Speed scales how fast the snake moves on every board.
+type Speed string
+
+const (
+	SpeedSlow   Speed = "slow"
+	SpeedNormal Speed = "normal"
+	SpeedFast   Speed = "fast"
+)
+
+// Speeds lists the speeds in the order the settings screen cycles them.
+var Speeds = []Speed{SpeedSlow, SpeedNormal, SpeedFast}
+
+// Interval scales a board's time between moves.
+func (s Speed) Interval(base time.Duration) time.Duration {
+	switch s {
+	case SpeedSlow:
+		return base * 4 / 3
+	case SpeedFast:
+		return base * 3 / 4
+	default:
+		return base
+	}
+}
+
+// Settings are the player's preferences, kept in settings.json in the config
+// directory.
+type Settings struct {
+	Speed Speed `json:"speed"`
+	// WarmUpWalls walls in the warm-up board every game starts on. The
+	// numbered levels always keep their own layout.
+	WarmUpWalls bool   `json:"warm_up_walls"`
+	Theme       string `json:"theme"`
+	// Skin only applies once the player's best score has unlocked it.
+	Skin string `json:"skin"`
+	// Keys names the key binding preset; KeyOverrides rebinds single
+	// actions on top of it, e.g. {"pause": ["p"]}.
+	Keys         string              `json:"keys"`
+	KeyOverrides map[string][]string `json:"key_overrides,omitempty"`
+	ShowHelp     bool                `json:"show_help"`
+	Sound        bool                `json:"sound"`
+}
+
+// DefaultSettings are used for anything missing from the settings file.
+func DefaultSettings() Settings {
+	return Settings{
+		Speed:       SpeedNormal,
+		WarmUpWalls: true,
+		Theme:       "classic",
+		Skin:        "classic",
+		Keys:        "default",
+		ShowHelp:    true,
+	}
+}
+
+// ConfigDir returns the directory super_snake keeps its settings in,
+// following $XDG_CONFIG_HOME and defaulting to ~/.config/super_snake.
+func ConfigDir() (string, error) {
+	base := os.Getenv("XDG_CONFIG_HOME")
+	if base == "" && utils.IsWindowsMachine() {
+		base = os.Getenv("APPDATA")
+	}
+
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+
+		base = filepath.Join(home, ".config")
+	}
+
+	return filepath.Join(base, appDirName), nil
+}
+
+// LoadSettings reads the settings file. A missing file gives the defaults; a
+// file that cannot be read gives the defaults along with the error.
+func LoadSettings() (Settings, error) {
+	settings := DefaultSettings()
+
+	dir, err := ConfigDir()
+	if err != nil {
+		return settings, err
+	}
+
+	path := filepath.Join(dir, settingsFileName)
+	contents, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return settings, nil
+	}
+
+	if err != nil {
+		return settings, err
+	}
+
+	if err := json.Unmarshal(contents, &settings); err != nil {
+		return DefaultSettings(), fmt.Errorf("%s: %w", path, err)
+	}
+
+	if !slices.Contains(Speeds, settings.Speed) {
+		settings.Speed = SpeedNormal
+	}
+
+	return settings, nil
+}
+
+// SaveSettings writes settings to the settings file, creating the config
+// directory if needed.
+func SaveSettings(settings Settings) error {
+	dir, err := ConfigDir()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	contents, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, settingsFileName), contents, 0o644)
+}
@@ -0,0 +1,101 @@
+package internal_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/the-Jinxist/golang_snake_game/internal"
+)
+
+func TestLoadSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		want     func(settings *internal.Settings)
+		wantErr  bool
+	}{
+		{name: "no file", want: func(*internal.Settings) {}},
+		{
+			name:     "partial file",
+			contents: `{"speed": "fast", "sound": true}`,
+			want: func(settings *internal.Settings) {
+				settings.Speed = internal.SpeedFast
+				settings.Sound = true
+			},
+		},
+		{
+			name:     "unknown speed",
+			contents: `{"speed": "ludicrous", "theme": "neon"}`,
+			want:     func(settings *internal.Settings) { settings.Theme = "neon" },
+		},
+		{name: "not json", contents: `speed: fast`, want: func(*internal.Settings) {}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			t.Setenv("XDG_CONFIG_HOME", dir)
+
+			if test.contents != "" {
+				path := filepath.Join(dir, "super_snake", "settings.json")
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+
+				if err := os.WriteFile(path, []byte(test.contents), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			settings, err := internal.LoadSettings()
+			if (err != nil) != test.wantErr {
+				t.Fatalf("LoadSettings() error = %v, want error: %t", err, test.wantErr)
+			}
+
+			want := internal.DefaultSettings()
+			test.want(&want)
+			if settings.Speed != want.Speed || settings.Theme != want.Theme || settings.Sound != want.Sound || settings.Skin != want.Skin {
+				t.Fatalf("LoadSettings() = %+v, want %+v", settings, want)
+			}
+		})
+	}
+}
+
+func TestAppSaveSettings(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	app := &internal.App{Settings: internal.DefaultSettings(), ThemeOverride: "neon"}
+
+	// Changing another setting keeps the one-off --theme and does not save
+	// it.
+	settings := app.Settings
+	settings.Sound = true
+	if err := app.SaveSettings(settings); err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := internal.LoadSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !saved.Sound || saved.Theme != "classic" || app.ThemeOverride != "neon" {
+		t.Fatalf("after turning sound on: saved %+v, override %q; want sound on, classic saved and neon kept for this run", saved, app.ThemeOverride)
+	}
+
+	// Picking a theme replaces the override.
+	settings.Theme = "ocean"
+	if err := app.SaveSettings(settings); err != nil {
+		t.Fatal(err)
+	}
+
+	if app.ThemeOverride != "" {
+		t.Fatalf("override after picking a theme = %q, want none", app.ThemeOverride)
+	}
+
+	guest := &internal.App{Guest: true, Settings: internal.DefaultSettings()}
+	if err := guest.SaveSettings(settings); err == nil {
+		t.Fatal("a guest's settings were saved")
+	}
+}
@@ -0,0 +1,153 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+var _ StatsStore = &StatsStoreImpl{}
+
+type DeathCause string
+
+const (
+	DeathNone   DeathCause = ""
+	DeathWall   DeathCause = "wall"
+	DeathPillar DeathCause = "pillar"
+	DeathSelf   DeathCause = "self"
+)
+
+// DeathCauses lists every way a run can end.
+var DeathCauses = []DeathCause{DeathWall, DeathPillar, DeathSelf}
+
+// GameStats is what GameModel reports when a level ends. Score and Duration
+// are totals for the whole run; FoodEaten covers only the level just played.
+type GameStats struct {
+	User        string
+	Session     string
+	Level       int
+	Score       int
+	FoodEaten   int
+	SnakeLength int
+	Duration    time.Duration
+	DeathCause  DeathCause
+}
+
+type PlayerStats struct {
+	GamesPlayed  int
+	FoodEaten    int
+	AverageScore float64
+	BestScore    int
+	LongestSnake int
+	PlayTime     time.Duration
+	Deaths       map[DeathCause]int
+	RecentScores []int
+}
+
+type StatsStore interface {
+	RecordGame(ctx context.Context, stats GameStats) error
+	GetPlayerStats(ctx context.Context, user string, recent int) (PlayerStats, error)
+}
+
+func NewStatsStore(db *sql.DB) StatsStore {
+	return &StatsStoreImpl{
+		db: db,
+	}
+}
+
+type StatsStoreImpl struct {
+	db *sql.DB
+}
+
+// RecordGame implements StatsStore. Reports for the same session are merged
+// into one row so a run across several levels counts as one game.
+func (s *StatsStoreImpl) RecordGame(ctx context.Context, stats GameStats) error {
+	_, err := s.db.ExecContext(ctx, `
+	insert into game_stats (session, "user", level, score, food_eaten, snake_length, duration_ms, death_cause)
+	values (?, ?, ?, ?, ?, ?, ?, ?)
+	on conflict(session) do update set
+		level = excluded.level,
+		score = excluded.score,
+		food_eaten = game_stats.food_eaten + excluded.food_eaten,
+		snake_length = max(game_stats.snake_length, excluded.snake_length),
+		duration_ms = excluded.duration_ms,
+		death_cause = excluded.death_cause,
+		updated_at = current_timestamp`,
+		stats.Session, stats.User, stats.Level, stats.Score, stats.FoodEaten,
+		stats.SnakeLength, stats.Duration.Milliseconds(), stats.DeathCause,
+	)
+	return err
+}
+
+// GetPlayerStats implements StatsStore. RecentScores holds the scores of up
+// to recent of the latest games, in the order they were started. That order
+// comes from the row, as timestamps only have whole seconds.
+func (s *StatsStoreImpl) GetPlayerStats(ctx context.Context, user string, recent int) (PlayerStats, error) {
+	stats := PlayerStats{
+		Deaths: make(map[DeathCause]int),
+	}
+
+	var playTimeMs int64
+	err := s.db.QueryRowContext(ctx, `
+	select count(*), coalesce(sum(food_eaten), 0), coalesce(avg(score), 0), coalesce(max(score), 0),
+		coalesce(max(snake_length), 0), coalesce(sum(duration_ms), 0)
+	from game_stats where "user" = ?`, user,
+	).Scan(
+		&stats.GamesPlayed,
+		&stats.FoodEaten,
+		&stats.AverageScore,
+		&stats.BestScore,
+		&stats.LongestSnake,
+		&playTimeMs,
+	)
+	if err != nil {
+		return stats, err
+	}
+
+	stats.PlayTime = time.Duration(playTimeMs) * time.Millisecond
+
+	rows, err := s.db.QueryContext(ctx, `
+	select death_cause, count(*) from game_stats
+	where "user" = ? and death_cause != '' group by death_cause`, user)
+	if err != nil {
+		return stats, err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			cause DeathCause
+			count int
+		)
+
+		if err := rows.Scan(&cause, &count); err != nil {
+			return stats, err
+		}
+
+		stats.Deaths[cause] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return stats, err
+	}
+
+	recentRows, err := s.db.QueryContext(ctx, `
+	select score from (
+		select score, rowid as seq from game_stats where "user" = ? order by rowid desc limit ?
+	) order by seq asc`, user, recent)
+	if err != nil {
+		return stats, err
+	}
+
+	defer recentRows.Close()
+	for recentRows.Next() {
+		var score int
+		if err := recentRows.Scan(&score); err != nil {
+			return stats, err
+		}
+
+		stats.RecentScores = append(stats.RecentScores, score)
+	}
+
+	return stats, recentRows.Err()
+}
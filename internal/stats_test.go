@@ -0,0 +1,73 @@
+package internal_test
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/the-Jinxist/golang_snake_game/internal"
+)
+
+func TestPlayerStats(t *testing.T) {
+	stats := internal.NewStatsStore(openDB(t))
+	ctx := context.Background()
+
+	// The first run is reported once per level it reached, and the other
+	// player's game must not count.
+	games := []internal.GameStats{
+		{User: "alice", Session: "first", Level: 1, Score: 20, FoodEaten: 2, SnakeLength: 3, Duration: time.Minute},
+		{User: "alice", Session: "second", Level: 1, Score: 10, FoodEaten: 1, SnakeLength: 2, Duration: 30 * time.Second, DeathCause: internal.DeathSelf},
+		{User: "alice", Session: "first", Level: 2, Score: 50, FoodEaten: 3, SnakeLength: 5, Duration: 2 * time.Minute, DeathCause: internal.DeathWall},
+		{User: "alice", Session: "third", Level: 1, Score: 40, FoodEaten: 4, SnakeLength: 4, Duration: time.Minute, DeathCause: internal.DeathWall},
+		{User: "bob", Session: "other", Level: 5, Score: 900, FoodEaten: 90, SnakeLength: 40, Duration: time.Hour, DeathCause: internal.DeathPillar},
+	}
+
+	for _, game := range games {
+		if err := stats.RecordGame(ctx, game); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := stats.GetPlayerStats(ctx, "alice", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := internal.PlayerStats{
+		GamesPlayed:  3,
+		FoodEaten:    10,
+		AverageScore: 100.0 / 3,
+		BestScore:    50,
+		LongestSnake: 5,
+		PlayTime:     3*time.Minute + 30*time.Second,
+		Deaths:       map[internal.DeathCause]int{internal.DeathWall: 2, internal.DeathSelf: 1},
+		// The last two games started, oldest first.
+		RecentScores: []int{10, 40},
+	}
+
+	if got.GamesPlayed != want.GamesPlayed || got.FoodEaten != want.FoodEaten || got.AverageScore != want.AverageScore ||
+		got.BestScore != want.BestScore || got.LongestSnake != want.LongestSnake || got.PlayTime != want.PlayTime {
+		t.Fatalf("stats = %+v, want %+v", got, want)
+	}
+
+	if !maps.Equal(got.Deaths, want.Deaths) {
+		t.Fatalf("deaths = %v, want %v", got.Deaths, want.Deaths)
+	}
+
+	if !slices.Equal(got.RecentScores, want.RecentScores) {
+		t.Fatalf("recent scores = %v, want %v", got.RecentScores, want.RecentScores)
+	}
+}
+
+func TestPlayerStatsWithoutGames(t *testing.T) {
+	stats, err := internal.NewStatsStore(openDB(t)).GetPlayerStats(context.Background(), "alice", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stats.GamesPlayed != 0 || stats.BestScore != 0 || len(stats.Deaths) != 0 || len(stats.RecentScores) != 0 {
+		t.Fatalf("stats = %+v, want nothing played", stats)
+	}
+}
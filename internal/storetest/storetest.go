@@ -0,0 +1,597 @@
+// Package storetest checks a storage backend against the behaviour the game
+// relies on from a ScoreService, in the style of testing/fstest. Every
+// backend (SQLite, the JSON file store and the remote store) is expected to
+// pass it, so that the rest of the game can treat them interchangeably.
+//
+// Scores are recorded with replays played through the game engine, so a
+// remote store in front of a verifying leaderboard server accepts them.
+package storetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/the-Jinxist/golang_snake_game/internal"
+	"github.com/the-Jinxist/golang_snake_game/tui/game"
+	"github.com/the-Jinxist/golang_snake_game/tui/game/gametest"
+)
+
+// Store is one backend under test: a score service and the session manager
+// it records sessions with. Shared is set for a store whose boards are read
+// from a leaderboard server, which only ranks runs it has replayed.
+type Store struct {
+	Scores   internal.ScoreService
+	Sessions internal.SessionManager
+	Shared   bool
+}
+
+// NewStore opens an empty store with user as the current player. It is called
+// once per check so checks never see each other's scores.
+type NewStore func(user string) (Store, error)
+
+const player = "alice"
+
+type check struct {
+	name string
+	run  func(ctx context.Context, store Store) error
+}
+
+var checks = []check{
+	{"empty store", checkEmpty},
+	{"current score", checkCurrentScore},
+	{"score name", checkScoreName},
+	{"deaths", checkDeaths},
+	{"ranking", checkRanking},
+	{"replays", checkReplays},
+	{"submit", checkSubmit},
+	{"import", checkImport},
+	{"players", checkPlayers},
+}
+
+// TestScoreService runs every check against a fresh store from newStore and
+// returns an error describing each one that failed.
+func TestScoreService(newStore NewStore) error {
+	var failures []error
+
+	for _, check := range checks {
+		store, err := newStore(player)
+		if err != nil {
+			return fmt.Errorf("open store: %w", err)
+		}
+
+		err = check.run(context.Background(), store)
+		if err != nil {
+			failures = append(failures, fmt.Errorf("%s: %w", check.name, err))
+		}
+	}
+
+	return errors.Join(failures...)
+}
+
+func checkEmpty(ctx context.Context, store Store) error {
+	count, err := store.Scores.CountScores(ctx, internal.ScoreQuery{})
+	if err != nil {
+		return err
+	}
+
+	if count != 0 {
+		return fmt.Errorf("CountScores = %d, want 0", count)
+	}
+
+	scores, err := store.Scores.GetScores(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(scores) != 0 {
+		return fmt.Errorf("GetScores returned %d scores, want none", len(scores))
+	}
+
+	high, err := store.Scores.GetHighScore(ctx)
+	if err != nil {
+		return err
+	}
+
+	if high.Value != 0 {
+		return fmt.Errorf("GetHighScore = %d, want 0", high.Value)
+	}
+
+	best, err := store.Scores.GetPersonalBest(ctx)
+	if err != nil {
+		return err
+	}
+
+	if best.Value != 0 {
+		return fmt.Errorf("GetPersonalBest = %d, want 0", best.Value)
+	}
+
+	return nil
+}
+
+func checkCurrentScore(ctx context.Context, store Store) error {
+	warmUp, err := run(0, 10, false)
+	if err != nil {
+		return err
+	}
+
+	levelOne, err := run(1, 30, false)
+	if err != nil {
+		return err
+	}
+
+	if err := record(ctx, store, warmUp, 5*time.Second); err != nil {
+		return err
+	}
+
+	if err := record(ctx, store, levelOne, 7*time.Second); err != nil {
+		return err
+	}
+
+	current, err := store.Scores.GetCurrentScore(ctx)
+	if err != nil {
+		return err
+	}
+
+	board := boardName(1)
+	switch {
+	case current.Value != 30:
+		return fmt.Errorf("current score value = %d, want the latest write, 30", current.Value)
+	case current.Duration != 7*time.Second:
+		return fmt.Errorf("current score duration = %s, want 7s", current.Duration)
+	case current.User != player:
+		return fmt.Errorf("current score user = %q, want %q", current.User, player)
+	case current.Level != 1 || current.Mode != internal.GameModeClassic || current.Board != board:
+		return fmt.Errorf("current score run = %d/%s/%s, want 1/classic/%s", current.Level, current.Mode, current.Board, board)
+	}
+
+	count, err := store.Scores.CountScores(ctx, internal.ScoreQuery{})
+	if err != nil {
+		return err
+	}
+
+	if count != 1 {
+		return fmt.Errorf("CountScores = %d after two writes to one session, want 1", count)
+	}
+
+	return nil
+}
+
+func checkScoreName(ctx context.Context, store Store) error {
+	if err := play(ctx, store, 0, 10); err != nil {
+		return err
+	}
+
+	if err := store.Scores.SetCurrentScoreName(ctx, "ACE"); err != nil {
+		return err
+	}
+
+	current, err := store.Scores.GetCurrentScore(ctx)
+	if err != nil {
+		return err
+	}
+
+	if current.DisplayName() != "ACE" {
+		return fmt.Errorf("display name = %q, want ACE", current.DisplayName())
+	}
+
+	return nil
+}
+
+func checkDeaths(ctx context.Context, store Store) error {
+	ate, err := run(0, 10, true)
+	if err != nil {
+		return err
+	}
+
+	starved, err := run(0, 0, true)
+	if err != nil {
+		return err
+	}
+
+	if ate.Score.DeathX == starved.Score.DeathX && ate.Score.DeathY == starved.Score.DeathY {
+		return errors.New("fixture runs die in the same cell")
+	}
+
+	for _, died := range []gametest.Run{ate, ate, starved} {
+		if err := record(ctx, store, died, time.Second); err != nil {
+			return err
+		}
+
+		if err := store.Scores.SetCurrentDeath(ctx, died.Score.DeathCause, died.Score.DeathX, died.Score.DeathY); err != nil {
+			return err
+		}
+
+		if err := store.Sessions.FinishCurrentSession(); err != nil {
+			return err
+		}
+	}
+
+	hotspots, err := store.Scores.GetDeathHotspots(ctx, internal.DeathQuery{})
+	if err != nil {
+		return err
+	}
+
+	if len(hotspots) != 2 {
+		return fmt.Errorf("GetDeathHotspots returned %d cells, want 2", len(hotspots))
+	}
+
+	top := hotspots[0]
+	if top.X != ate.Score.DeathX || top.Y != ate.Score.DeathY || top.Deaths != 2 || top.Cause != internal.DeathWall || top.Level != 0 {
+		return fmt.Errorf("top hotspot = %+v, want 2 wall deaths at %d,%d on level 0", top, ate.Score.DeathX, ate.Score.DeathY)
+	}
+
+	// A limit below 1 means no limit, like every other zero value.
+	for _, query := range []struct {
+		query internal.DeathQuery
+		want  int
+	}{
+		{query: internal.DeathQuery{Limit: 1}, want: 1},
+		{query: internal.DeathQuery{Limit: -1}, want: 2},
+		{query: internal.DeathQuery{Cause: internal.DeathWall}, want: 2},
+		{query: internal.DeathQuery{Cause: internal.DeathSelf}, want: 0},
+	} {
+		hotspots, err := store.Scores.GetDeathHotspots(ctx, query.query)
+		if err != nil {
+			return err
+		}
+
+		if len(hotspots) != query.want {
+			return fmt.Errorf("GetDeathHotspots(%+v) returned %d cells, want %d", query.query, len(hotspots), query.want)
+		}
+	}
+
+	return nil
+}
+
+func checkRanking(ctx context.Context, store Store) error {
+	for _, run := range []struct{ level, value int }{{1, 40}, {0, 10}, {1, 30}} {
+		if err := play(ctx, store, run.level, run.value); err != nil {
+			return err
+		}
+
+		if err := store.Sessions.FinishCurrentSession(); err != nil {
+			return err
+		}
+	}
+
+	scores, err := store.Scores.QueryScores(ctx, internal.ScoreQuery{})
+	if err != nil {
+		return err
+	}
+
+	if got := values(scores); !slices.Equal(got, []int{40, 30, 10}) {
+		return fmt.Errorf("QueryScores values = %v, want [40 30 10]", got)
+	}
+
+	for index, score := range scores {
+		if score.Rank != index+1 {
+			return fmt.Errorf("score %d has rank %d, want %d", score.Value, score.Rank, index+1)
+		}
+	}
+
+	level := 1
+	scores, err = store.Scores.QueryScores(ctx, internal.ScoreQuery{Level: &level})
+	if err != nil {
+		return err
+	}
+
+	if got := values(scores); !slices.Equal(got, []int{40, 30}) {
+		return fmt.Errorf("level 1 values = %v, want [40 30]", got)
+	}
+
+	scores, err = store.Scores.QueryScores(ctx, internal.ScoreQuery{Limit: 1, Offset: 1})
+	if err != nil {
+		return err
+	}
+
+	if got := values(scores); !slices.Equal(got, []int{30}) {
+		return fmt.Errorf("second page of one = %v, want [30]", got)
+	}
+
+	// An offset applies without a limit, and a negative limit means none.
+	for _, page := range []struct {
+		query internal.ScoreQuery
+		want  []int
+	}{
+		{query: internal.ScoreQuery{Offset: 1}, want: []int{30, 10}},
+		{query: internal.ScoreQuery{Offset: 5}, want: []int{}},
+		{query: internal.ScoreQuery{Limit: -1}, want: []int{40, 30, 10}},
+	} {
+		scores, err := store.Scores.QueryScores(ctx, page.query)
+		if err != nil {
+			return err
+		}
+
+		if got := values(scores); !slices.Equal(got, page.want) {
+			return fmt.Errorf("QueryScores(%+v) values = %v, want %v", page.query, got, page.want)
+		}
+	}
+
+	scores, err = store.Scores.QueryScores(ctx, internal.ScoreQuery{SortBy: internal.SortByScore, Ascending: true})
+	if err != nil {
+		return err
+	}
+
+	if got := values(scores); !slices.Equal(got, []int{10, 30, 40}) {
+		return fmt.Errorf("ascending values = %v, want [10 30 40]", got)
+	}
+
+	count, err := store.Scores.CountScores(ctx, internal.ScoreQuery{Level: &level})
+	if err != nil {
+		return err
+	}
+
+	if count != 2 {
+		return fmt.Errorf("level 1 count = %d, want 2", count)
+	}
+
+	high, err := store.Scores.GetHighScore(ctx)
+	if err != nil {
+		return err
+	}
+
+	if high.Value != 40 {
+		return fmt.Errorf("GetHighScore = %d, want 40", high.Value)
+	}
+
+	return nil
+}
+
+func checkReplays(ctx context.Context, store Store) error {
+	first := internal.LevelReplay{Level: 1, Seed: 7, Ticks: 40, Inputs: []internal.ReplayInput{{Tick: 3, Direction: 2}}}
+	second := internal.LevelReplay{Level: 2, Seed: 8, StartScore: 10, Ticks: 12}
+
+	for _, replay := range []internal.LevelReplay{second, {Level: 1, Seed: 1}, first} {
+		if err := store.Scores.SetCurrentReplay(ctx, replay); err != nil {
+			return err
+		}
+	}
+
+	session, err := store.Sessions.GetCurrentSession()
+	if err != nil {
+		return err
+	}
+
+	replay, err := store.Scores.GetReplay(ctx, session)
+	if err != nil {
+		return err
+	}
+
+	if len(replay) != 2 || !sameReplay(replay[0], first) || !sameReplay(replay[1], second) {
+		return fmt.Errorf("GetReplay = %+v, want levels 1 and 2 in order with the latest level 1", replay)
+	}
+
+	return nil
+}
+
+func checkSubmit(ctx context.Context, store Store) error {
+	first, err := run(1, 40, false)
+	if err != nil {
+		return err
+	}
+
+	second, err := run(1, 50, false)
+	if err != nil {
+		return err
+	}
+
+	for _, submitted := range []gametest.Run{first, second} {
+		score := submitted.Score
+		score.User, score.Session, score.Duration = "bob", "submitted", 3*time.Second
+
+		if err := store.Scores.SubmitScore(ctx, score, submitted.Replay); err != nil {
+			return err
+		}
+	}
+
+	// Another player cannot take over the session, nor its replay.
+	taken := first.Score
+	taken.User, taken.Session = "mallory", "submitted"
+	if err := store.Scores.SubmitScore(ctx, taken, first.Replay); !errors.Is(err, internal.ErrSessionTaken) {
+		return fmt.Errorf("submitting another player's session = %v, want %v", err, internal.ErrSessionTaken)
+	}
+
+	scores, err := store.Scores.QueryScores(ctx, internal.ScoreQuery{})
+	if err != nil {
+		return err
+	}
+
+	if len(scores) != 1 || scores[0].Value != 50 || scores[0].User != "bob" {
+		return fmt.Errorf("scores after submitting = %+v, want bob's 50 only", scores)
+	}
+
+	stored, err := store.Scores.GetReplay(ctx, "submitted")
+	if err != nil {
+		return err
+	}
+
+	if !slices.EqualFunc(stored, second.Replay, sameReplay) {
+		return fmt.Errorf("submitted replay = %+v, want %+v", stored, second.Replay)
+	}
+
+	return nil
+}
+
+func checkImport(ctx context.Context, store Store) error {
+	scores := []internal.Score{
+		{User: "carol", Session: "imported-1", Value: 7, Verification: internal.Verified},
+		{User: "carol", Session: "imported-2", Value: 9},
+	}
+
+	imported, err := store.Scores.ImportScores(ctx, scores)
+	if err != nil {
+		return err
+	}
+
+	if imported != 2 {
+		return fmt.Errorf("ImportScores = %d, want 2", imported)
+	}
+
+	imported, err = store.Scores.ImportScores(ctx, scores)
+	if err != nil {
+		return err
+	}
+
+	if imported != 0 {
+		return fmt.Errorf("importing the same scores again = %d, want 0", imported)
+	}
+
+	stored, err := store.Scores.QueryScores(ctx, internal.ScoreQuery{Player: "carol"})
+	if err != nil {
+		return err
+	}
+
+	// Imported scores have no replays, so a shared board leaves them out.
+	want := 2
+	if store.Shared {
+		want = 0
+	}
+
+	if len(stored) != want {
+		return fmt.Errorf("carol has %d scores after importing, want %d", len(stored), want)
+	}
+
+	for _, score := range stored {
+		if score.Verification != internal.Unverified {
+			return fmt.Errorf("imported score %s is %q, want unverified", score.Session, score.Verification)
+		}
+	}
+
+	return nil
+}
+
+func checkPlayers(ctx context.Context, store Store) error {
+	for _, run := range []struct{ level, value int }{{1, 40}, {0, 10}} {
+		if err := play(ctx, store, run.level, run.value); err != nil {
+			return err
+		}
+
+		if err := store.Sessions.FinishCurrentSession(); err != nil {
+			return err
+		}
+	}
+
+	use(store, "bob")
+	if err := play(ctx, store, 1, 30); err != nil {
+		return err
+	}
+
+	best, err := store.Scores.GetPersonalBest(ctx)
+	if err != nil {
+		return err
+	}
+
+	if best.Value != 30 || best.User != "bob" {
+		return fmt.Errorf("bob's personal best = %d by %q, want 30", best.Value, best.User)
+	}
+
+	use(store, player)
+	best, err = store.Scores.GetPersonalBest(ctx)
+	if err != nil {
+		return err
+	}
+
+	if best.Value != 40 {
+		return fmt.Errorf("alice's personal best = %d, want 40", best.Value)
+	}
+
+	history, err := store.Scores.GetHistory(ctx, 1)
+	if err != nil {
+		return err
+	}
+
+	if got := values(history); !slices.Equal(got, []int{10}) {
+		return fmt.Errorf("alice's latest game = %v, want [10]", got)
+	}
+
+	return nil
+}
+
+// play records a run that reaches value on level as the current session's
+// score.
+func play(ctx context.Context, store Store, level, value int) error {
+	played, err := run(level, value, false)
+	if err != nil {
+		return err
+	}
+
+	return record(ctx, store, played, time.Second)
+}
+
+// record writes played to the current session the way the game does: each
+// level's replay, then the run and the score it reached.
+func record(ctx context.Context, store Store, played gametest.Run, duration time.Duration) error {
+	level := played.Score.Level
+	if err := store.Sessions.UpdateCurrentSession(level, 1); err != nil {
+		return err
+	}
+
+	for _, replay := range played.Replay {
+		if err := store.Scores.SetCurrentReplay(ctx, replay); err != nil {
+			return err
+		}
+	}
+
+	if err := store.Scores.SetCurrentRun(ctx, internal.RunInfo{Level: level, Board: boardName(level)}); err != nil {
+		return err
+	}
+
+	return store.Scores.SetCurrentScore(ctx, played.Score.Value, duration)
+}
+
+// runs caches played runs, as finding one takes a seed search.
+var runs = struct {
+	sync.Mutex
+	played map[[3]int]gametest.Run
+}{played: make(map[[3]int]gametest.Run)}
+
+// run returns a run from the warm-up board that reaches value on level,
+// dying there if die is set.
+func run(level, value int, die bool) (gametest.Run, error) {
+	runs.Lock()
+	defer runs.Unlock()
+
+	key := [3]int{level, value, 0}
+	if die {
+		key[2] = 1
+	}
+
+	if played, ok := runs.played[key]; ok {
+		return played, nil
+	}
+
+	played, err := gametest.Play(level, value, die)
+	if err != nil {
+		return played, err
+	}
+
+	runs.played[key] = played
+	return played, nil
+}
+
+func boardName(level int) string {
+	return game.LevelGameConfig(nil, level).BoardName()
+}
+
+func use(store Store, user string) {
+	store.Scores.SetCurrentUser(user)
+	store.Sessions.SetUser(user)
+}
+
+func values(scores []internal.Score) []int {
+	values := make([]int, 0, len(scores))
+	for _, score := range scores {
+		values = append(values, score.Value)
+	}
+
+	return values
+}
+
+func sameReplay(a, b internal.LevelReplay) bool {
+	return a.Level == b.Level && a.Seed == b.Seed && a.StartScore == b.StartScore &&
+		a.Ticks == b.Ticks && slices.Equal(a.Inputs, b.Inputs)
+}
@@ -0,0 +1,95 @@
+package storetest_test
+
+import (
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/the-Jinxist/golang_snake_game/internal"
+	"github.com/the-Jinxist/golang_snake_game/internal/storetest"
+	"github.com/the-Jinxist/golang_snake_game/server"
+	"github.com/the-Jinxist/golang_snake_game/tui/game"
+)
+
+func TestSQLite(t *testing.T) {
+	check(t, func(user string) (storetest.Store, error) {
+		return sqliteStore(t, user)
+	})
+}
+
+func TestJSON(t *testing.T) {
+	check(t, func(user string) (storetest.Store, error) {
+		store, err := internal.OpenJSONStore(filepath.Join(t.TempDir(), "scores.json"), user)
+		if err != nil {
+			return storetest.Store{}, err
+		}
+
+		return storetest.Store{Scores: store.Scores(), Sessions: store.Sessions()}, nil
+	})
+}
+
+func TestMemory(t *testing.T) {
+	check(t, func(user string) (storetest.Store, error) {
+		return memoryStore(user), nil
+	})
+}
+
+// TestRemote runs the checks through a leaderboard server that verifies
+// replays the way a real one does, with a SQLite database on each side.
+func TestRemote(t *testing.T) {
+	check(t, func(user string) (storetest.Store, error) {
+		// The submission queue lives in the data directory.
+		t.Setenv("XDG_DATA_HOME", t.TempDir())
+
+		shared, err := sqliteStore(t, "")
+		if err != nil {
+			return storetest.Store{}, err
+		}
+
+		leaderboard := httptest.NewServer(server.New(shared.Scores, game.ReplayVerifier{}))
+		t.Cleanup(leaderboard.Close)
+
+		local, err := sqliteStore(t, user)
+		if err != nil {
+			return storetest.Store{}, err
+		}
+
+		scores, err := internal.NewRemoteScoreService(leaderboard.URL, local.Scores)
+		if err != nil {
+			return storetest.Store{}, err
+		}
+
+		return storetest.Store{Scores: scores, Sessions: local.Sessions, Shared: true}, nil
+	})
+}
+
+func check(t *testing.T, newStore storetest.NewStore) {
+	t.Helper()
+
+	if err := storetest.TestScoreService(newStore); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func sqliteStore(t *testing.T, user string) (storetest.Store, error) {
+	db, err := internal.CreateDB(filepath.Join(t.TempDir(), "scores.db"))
+	if err != nil {
+		return storetest.Store{}, err
+	}
+
+	t.Cleanup(func() { db.Close() })
+
+	sessions, err := internal.NewSQLiteSessionManager(user, db)
+	if err != nil {
+		return storetest.Store{}, err
+	}
+
+	return storetest.Store{Scores: internal.NewScoreService(user, sessions, db), Sessions: sessions}, nil
+}
+
+func memoryStore(user string) storetest.Store {
+	sessions := internal.NewSessionManager()
+	sessions.SetUser(user)
+
+	return storetest.Store{Scores: internal.NewInMemoryScoreService(user, sessions), Sessions: sessions}
+}
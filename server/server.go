@@ -0,0 +1,199 @@
+// Package server exposes a ScoreService as a small REST API so several
+// players can share one leaderboard.
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/the-Jinxist/golang_snake_game/internal"
+)
+
+const (
+	defaultLimit = 10
+	maxLimit     = 100
+)
+
+// Verifier checks a submitted score against the replay of the run that
+// produced it.
+type Verifier interface {
+	Verify(score internal.Score, replay []internal.LevelReplay) error
+}
+
+// Server serves the leaderboard API:
+//
+//	POST /api/scores                submit or update a score with its replay
+//	GET  /api/scores                list scores, filtered like `scores list`
+//	GET  /api/scores/count          count the scores matching the same filters
+//	GET  /api/levels/{level}/scores list the board for one level
+//
+// Every submission must carry a replay, which the verifier re-simulates:
+// those that do not reproduce their score are rejected, the rest are stored
+// as verified, so only checked runs are ranked. A session already submitted
+// by another player is refused.
+type Server struct {
+	scores   internal.ScoreService
+	verifier Verifier
+	mux      *http.ServeMux
+}
+
+// New returns a Server storing scores in scores and checking replays with
+// verifier.
+func New(scores internal.ScoreService, verifier Verifier) *Server {
+	s := &Server{
+		scores:   scores,
+		verifier: verifier,
+		mux:      http.NewServeMux(),
+	}
+
+	s.mux.HandleFunc("POST /api/scores", s.submitScore)
+	s.mux.HandleFunc("GET /api/scores", s.listScores)
+	s.mux.HandleFunc("GET /api/scores/count", s.countScores)
+	s.mux.HandleFunc("GET /api/levels/{level}/scores", s.listLevelScores)
+
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) submitScore(w http.ResponseWriter, r *http.Request) {
+	var record internal.ScoreRecord
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&record); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := validateRecord(record); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if len(record.Replay) == 0 {
+		writeError(w, http.StatusUnprocessableEntity, errors.New("a replay is required to rank a score"))
+		return
+	}
+
+	score := record.Score()
+	if err := s.verifier.Verify(score, record.Replay); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	score.Verification = internal.Verified
+	err := s.scores.SubmitScore(r.Context(), score, record.Replay)
+	if errors.Is(err, internal.ErrSessionTaken) {
+		writeError(w, http.StatusConflict, err)
+		return
+	}
+
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listScores(w http.ResponseWriter, r *http.Request) {
+	query, err := parseQuery(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	s.writeScores(w, r, query)
+}
+
+func (s *Server) listLevelScores(w http.ResponseWriter, r *http.Request) {
+	level, err := strconv.Atoi(r.PathValue("level"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, errors.New("level must be a number"))
+		return
+	}
+
+	query, err := parseQuery(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	query.Level = &level
+	s.writeScores(w, r, query)
+}
+
+func (s *Server) countScores(w http.ResponseWriter, r *http.Request) {
+	query, err := internal.ParseScoreQuery(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	count, err := s.scores.CountScores(r.Context(), query)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]int{"count": count})
+}
+
+func (s *Server) writeScores(w http.ResponseWriter, r *http.Request, query internal.ScoreQuery) {
+	scores, err := s.scores.QueryScores(r.Context(), query)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	records := make([]internal.ScoreRecord, 0, len(scores))
+	for _, score := range scores {
+		records = append(records, internal.NewScoreRecord(score))
+	}
+
+	writeJSON(w, http.StatusOK, records)
+}
+
+// parseQuery reads the list filters, capping the page size so a single
+// request cannot dump the whole table.
+func parseQuery(r *http.Request) (internal.ScoreQuery, error) {
+	query, err := internal.ParseScoreQuery(r.URL.Query())
+	if err != nil {
+		return query, err
+	}
+
+	if query.Limit == 0 {
+		query.Limit = defaultLimit
+	}
+
+	query.Limit = min(query.Limit, maxLimit)
+	return query, nil
+}
+
+func validateRecord(record internal.ScoreRecord) error {
+	switch {
+	case record.Session == "":
+		return errors.New("session is required")
+	case record.User == "":
+		return errors.New("user is required")
+	case record.Value < 0:
+		return errors.New("value cannot be negative")
+	case record.DurationMs < 0:
+		return errors.New("duration_ms cannot be negative")
+	}
+
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
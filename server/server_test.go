@@ -0,0 +1,266 @@
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/the-Jinxist/golang_snake_game/internal"
+	"github.com/the-Jinxist/golang_snake_game/server"
+	"github.com/the-Jinxist/golang_snake_game/tui/game"
+	"github.com/the-Jinxist/golang_snake_game/tui/game/gametest"
+)
+
+// newServer returns a server verifying replays in front of an in-memory
+// store holding recorded.
+func newServer(t *testing.T, recorded []internal.Score) (*server.Server, internal.ScoreService) {
+	t.Helper()
+
+	scores := internal.NewInMemoryScoreService("", internal.NewSessionManager())
+	if _, err := scores.ImportScores(context.Background(), recorded); err != nil {
+		t.Fatal(err)
+	}
+
+	return server.New(scores, game.ReplayVerifier{}), scores
+}
+
+func serve(handler http.Handler, method, target string, body any) *httptest.ResponseRecorder {
+	var data []byte
+	if body != nil {
+		data, _ = json.Marshal(body)
+	}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(method, target, bytes.NewReader(data)))
+	return recorder
+}
+
+// submission is a record of run as alice would submit it.
+func submission(t *testing.T, level, value int) internal.ScoreRecord {
+	t.Helper()
+
+	run, err := gametest.Play(level, value, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	run.Score.User = "alice"
+	run.Score.Session = fmt.Sprintf("alice-%d-%d", level, value)
+
+	record := internal.NewScoreRecord(run.Score)
+	record.Replay = run.Replay
+	return record
+}
+
+func TestSubmitScore(t *testing.T) {
+	tests := []struct {
+		name   string
+		record func(t *testing.T) any
+		want   int
+		// stored is the verification the score is stored with, if at all.
+		stored internal.Verification
+	}{
+		{
+			name:   "replay that checks out",
+			record: func(t *testing.T) any { return submission(t, 1, 30) },
+			want:   http.StatusNoContent,
+			stored: internal.Verified,
+		},
+		{
+			name: "replay that does not reach the score",
+			record: func(t *testing.T) any {
+				record := submission(t, 1, 30)
+				record.Value += 10
+				return record
+			},
+			want: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "claims to be verified",
+			record: func(t *testing.T) any {
+				record := submission(t, 1, 30)
+				record.Value += 10
+				record.Verification = internal.Verified
+				return record
+			},
+			want: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "no session",
+			record: func(t *testing.T) any {
+				record := submission(t, 0, 10)
+				record.Session = ""
+				return record
+			},
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "negative score",
+			record: func(t *testing.T) any {
+				record := submission(t, 0, 10)
+				record.Value = -10
+				return record
+			},
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "no replay",
+			record: func(t *testing.T) any {
+				record := submission(t, 1, 30)
+				record.Replay = nil
+				return record
+			},
+			want: http.StatusUnprocessableEntity,
+		},
+		{name: "not a score", record: func(t *testing.T) any { return []string{"alice"} }, want: http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler, scores := newServer(t, nil)
+
+			resp := serve(handler, http.MethodPost, "/api/scores", test.record(t))
+			if resp.Code != test.want {
+				t.Fatalf("POST /api/scores = %d %s, want %d", resp.Code, resp.Body, test.want)
+			}
+
+			stored, err := scores.QueryScores(context.Background(), internal.ScoreQuery{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if test.stored == "" {
+				if len(stored) != 0 {
+					t.Fatalf("stored %+v, want nothing", stored)
+				}
+
+				return
+			}
+
+			if len(stored) != 1 || stored[0].Verification != test.stored {
+				t.Fatalf("stored %+v, want one %s score", stored, test.stored)
+			}
+		})
+	}
+}
+
+func TestReadBoards(t *testing.T) {
+	var recorded []internal.Score
+	for index := range 120 {
+		recorded = append(recorded, internal.Score{
+			User:    "alice",
+			Session: fmt.Sprint(index),
+			Value:   index + 1,
+			Level:   index % 3,
+		})
+	}
+
+	tests := []struct {
+		name   string
+		target string
+		want   int
+		// count is how many scores come back, and first the value of the
+		// first of them.
+		count int
+		first int
+	}{
+		{name: "default page", target: "/api/scores", want: http.StatusOK, count: 10, first: 120},
+		{name: "page", target: "/api/scores?limit=5&offset=5", want: http.StatusOK, count: 5, first: 115},
+		{name: "capped page", target: "/api/scores?limit=500", want: http.StatusOK, count: 100, first: 120},
+		{name: "ascending", target: "/api/scores?order=asc&limit=1", want: http.StatusOK, count: 1, first: 1},
+		{name: "level filter", target: "/api/scores?level=1&limit=100", want: http.StatusOK, count: 40, first: 119},
+		{name: "level board", target: "/api/levels/2/scores?limit=3", want: http.StatusOK, count: 3, first: 120},
+		{name: "level that is not a number", target: "/api/levels/two/scores", want: http.StatusBadRequest},
+		{name: "negative limit", target: "/api/scores?limit=-1", want: http.StatusBadRequest},
+		{name: "unknown sort", target: "/api/scores?sort=food", want: http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler, _ := newServer(t, recorded)
+
+			resp := serve(handler, http.MethodGet, test.target, nil)
+			if resp.Code != test.want {
+				t.Fatalf("GET %s = %d %s, want %d", test.target, resp.Code, resp.Body, test.want)
+			}
+
+			if test.want != http.StatusOK {
+				return
+			}
+
+			var records []internal.ScoreRecord
+			if err := json.NewDecoder(resp.Body).Decode(&records); err != nil {
+				t.Fatal(err)
+			}
+
+			if len(records) != test.count || records[0].Value != test.first {
+				t.Fatalf("GET %s returned %d scores from %d, want %d from %d", test.target, len(records), records[0].Value, test.count, test.first)
+			}
+		})
+	}
+}
+
+func TestCountScores(t *testing.T) {
+	handler, _ := newServer(t, []internal.Score{
+		{User: "alice", Session: "a", Value: 10, Level: 1},
+		{User: "bob", Session: "b", Value: 20, Level: 1},
+		{User: "bob", Session: "c", Value: 30, Level: 2},
+	})
+
+	tests := []struct {
+		target string
+		want   int
+	}{
+		{target: "/api/scores/count", want: 3},
+		{target: "/api/scores/count?level=1", want: 2},
+		{target: "/api/scores/count?player=bob", want: 2},
+		// The count ignores paging.
+		{target: "/api/scores/count?limit=1", want: 3},
+	}
+
+	for _, test := range tests {
+		resp := serve(handler, http.MethodGet, test.target, nil)
+		if resp.Code != http.StatusOK {
+			t.Fatalf("GET %s = %d %s", test.target, resp.Code, resp.Body)
+		}
+
+		var body struct {
+			Count int `json:"count"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+
+		if body.Count != test.want {
+			t.Fatalf("GET %s counted %d, want %d", test.target, body.Count, test.want)
+		}
+	}
+}
+
+func TestSubmitAnotherPlayersSession(t *testing.T) {
+	handler, scores := newServer(t, nil)
+
+	owned := submission(t, 1, 30)
+	if resp := serve(handler, http.MethodPost, "/api/scores", owned); resp.Code != http.StatusNoContent {
+		t.Fatalf("POST /api/scores = %d %s", resp.Code, resp.Body)
+	}
+
+	taken := submission(t, 1, 40)
+	taken.User, taken.Session = "mallory", owned.Session
+	if resp := serve(handler, http.MethodPost, "/api/scores", taken); resp.Code != http.StatusConflict {
+		t.Fatalf("POST /api/scores for alice's session = %d %s, want %d", resp.Code, resp.Body, http.StatusConflict)
+	}
+
+	replay, err := scores.GetReplay(context.Background(), owned.Session)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(replay) != len(owned.Replay) || replay[0].Seed != owned.Replay[0].Seed || replay[0].Ticks != owned.Replay[0].Ticks {
+		t.Fatalf("replay after mallory's submission = %+v, want alice's %+v", replay, owned.Replay)
+	}
+}
@@ -0,0 +1,97 @@
+package achievements
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/the-Jinxist/golang_snake_game/internal"
+	"github.com/the-Jinxist/golang_snake_game/tui/keys"
+	"github.com/the-Jinxist/golang_snake_game/tui/theme"
+	"github.com/the-Jinxist/golang_snake_game/tui/views"
+)
+
+var _ tea.Model = &AchievementsModel{}
+
+// styles are the screen's lipgloss styles in the player's theme.
+type styles struct {
+	unlocked lipgloss.Style
+	locked   lipgloss.Style
+	desc     lipgloss.Style
+	error    lipgloss.Style
+	help     lipgloss.Style
+}
+
+func newStyles(t theme.Theme) styles {
+	return styles{
+		unlocked: lipgloss.NewStyle().Bold(true).Foreground(t.Gold),
+		locked:   lipgloss.NewStyle().Foreground(t.Locked),
+		desc:     lipgloss.NewStyle().Italic(true).Foreground(t.Text),
+		error:    lipgloss.NewStyle().Foreground(t.Error),
+		help:     lipgloss.NewStyle().Foreground(t.Muted),
+	}
+}
+
+type AchievementsModel struct {
+	Config   AchievementsConfig
+	Unlocked map[string]internal.UnlockedAchievement
+	err      error
+	styles   styles
+}
+
+func NewAchievementsModel(config AchievementsConfig) *AchievementsModel {
+	player := config.ScoreService.GetCurrentUser()
+	unlocked, err := config.Achievements.GetUnlocked(context.Background(), player)
+
+	byID := make(map[string]internal.UnlockedAchievement, len(unlocked))
+	for _, achievement := range unlocked {
+		byID[achievement.ID] = achievement
+	}
+
+	return &AchievementsModel{
+		Config:   config,
+		Unlocked: byID,
+		err:      err,
+		styles:   newStyles(config.Theme),
+	}
+}
+
+// Init implements tea.Model.
+func (a *AchievementsModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model.
+func (a *AchievementsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		if key.Matches(msg, a.Config.Keys.Back) {
+			return a, tea.Batch(views.ClearScreen(), views.SwitchModeCmd(views.ModeMenu))
+		}
+	}
+
+	return a, nil
+}
+
+// View implements tea.Model.
+func (a *AchievementsModel) View() string {
+	view := fmt.Sprintf("\nACHIEVEMENTS (%d/%d)\n\n", len(a.Unlocked), len(internal.Achievements))
+
+	if a.err != nil {
+		view += a.styles.error.Render(fmt.Sprintf("Could not load achievements: %s", a.err)) + "\n\n"
+	}
+
+	for _, achievement := range internal.Achievements {
+		if unlocked, ok := a.Unlocked[achievement.ID]; ok {
+			view += a.styles.unlocked.Render("🏆 "+achievement.Title) + "\n"
+			view += a.styles.desc.Render(fmt.Sprintf("   %s · unlocked %s", achievement.Description, unlocked.UnlockedAt.Local().Format("Jan 2 2006"))) + "\n\n"
+			continue
+		}
+
+		view += a.styles.locked.Render("🔒 "+achievement.Title) + "\n"
+		view += a.styles.locked.Render("   "+achievement.Description) + "\n\n"
+	}
+
+	return view + a.styles.help.Render(fmt.Sprintf("Press [%s] to return back to menu screen", keys.Label(a.Config.Keys.Back)))
+}
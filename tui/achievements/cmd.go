@@ -0,0 +1,23 @@
+package achievements
+
+import (
+	"github.com/the-Jinxist/golang_snake_game/internal"
+	"github.com/the-Jinxist/golang_snake_game/tui/keys"
+	"github.com/the-Jinxist/golang_snake_game/tui/theme"
+)
+
+type AchievementsConfig struct {
+	Achievements internal.AchievementService
+	ScoreService internal.ScoreService
+	Keys         keys.KeyMap
+	Theme        theme.Theme
+}
+
+func DefaultAchievementsConfig(app *internal.App) AchievementsConfig {
+	return AchievementsConfig{
+		Achievements: app.Achievements,
+		ScoreService: app.Scores,
+		Keys:         keys.FromSettings(app.Settings),
+		Theme:        theme.FromApp(app),
+	}
+}
@@ -1,10 +1,15 @@
 package game
 
 import (
+	"context"
+	"fmt"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/the-Jinxist/golang_snake_game/internal"
+	"github.com/the-Jinxist/golang_snake_game/tui/keys"
+	"github.com/the-Jinxist/golang_snake_game/tui/skin"
+	"github.com/the-Jinxist/golang_snake_game/tui/theme"
 )
 
 type Tick struct{}
@@ -21,8 +26,133 @@ type GameStartConfig struct {
 	Scoring        int
 	IsDebugGrid    bool
 	FPS            time.Duration
+	Mode           string
 	ScoreService   internal.ScoreService
 	SessionManager internal.SessionManager
+	StatsStore     internal.StatsStore
+	Achievements   internal.AchievementService
+	Guest          bool
+	ShowHelp       bool
+	Sound          bool
+	Keys           keys.KeyMap
+	Theme          theme.Theme
+	Skin           skin.Skin
+	// Store is the absolute path of the store the game is played against,
+	// which a saved game is tied to.
+	Store string
+	// Best is the player's personal best when the level started, to tell
+	// which skins the run unlocks.
+	Best int
+
+	// app is the app the services came from, switched to in-memory
+	// services if the store fails so later levels carry on without it.
+	app *internal.App
+}
+
+// warmUpLevel is the board every game starts on before level 1.
+const warmUpLevel = 0
+
+// finalLevel is the last level; clearing it completes the game.
+const finalLevel = 5
+
+// withApp fills in the services config plays against and applies the
+// player's settings. A nil app leaves them unset, which is enough to look at
+// a level's rules.
+func withApp(config GameStartConfig, app *internal.App) GameStartConfig {
+	if app == nil {
+		return config
+	}
+
+	config.FPS = app.Settings.Speed.Interval(config.FPS)
+	config.ShowHelp = app.Settings.ShowHelp
+	config.Sound = app.Settings.Sound
+	config.Keys = keys.FromSettings(app.Settings)
+	config.Theme = theme.FromApp(app)
+
+	// A best that cannot be read only means playing in the default skin.
+	best, _ := app.Scores.GetPersonalBest(context.Background())
+	config.Best = best.Value
+	config.Skin = skin.FromSettings(app.Settings, best.Value)
+
+	config.ScoreService = app.Scores
+	config.SessionManager = app.Sessions
+	config.StatsStore = app.Stats
+	config.Achievements = app.Achievements
+	config.Guest = app.Guest
+	config.Store = app.StorePath
+	config.app = app
+	return config
+}
+
+// withoutPersistence swaps the services config plays against for in-memory
+// ones, so the run can still be played while the score store is failing.
+// The app is switched too, so the following levels keep the run's score.
+func (c GameStartConfig) withoutPersistence(storageErr error) GameStartConfig {
+	app := c.app
+	if app == nil {
+		app = &internal.App{Scores: c.ScoreService}
+	}
+
+	app.UseMemory(storageErr)
+
+	c.app = app
+	c.SessionManager = app.Sessions
+	c.ScoreService = app.Scores
+	c.StatsStore = nil
+	c.Achievements = nil
+	c.Guest = true
+	return c
+}
+
+// startLevel reads the score the run carries into the level and records the
+// level against the run's session.
+func (c GameStartConfig) startLevel(seed uint64) (internal.Score, error) {
+	currentScore, err := c.ScoreService.GetCurrentScore(context.Background())
+	if err != nil {
+		return currentScore, err
+	}
+
+	if err := c.SessionManager.UpdateCurrentSession(c.Level, seed); err != nil {
+		return currentScore, err
+	}
+
+	return currentScore, c.ScoreService.SetCurrentRun(context.Background(), c.RunInfo())
+}
+
+// BoardName identifies the board layout a score was set on, e.g. "35x25-walled".
+func (c GameStartConfig) BoardName() string {
+	walls := "open"
+	if c.IsWalled {
+		walls = "walled"
+	}
+
+	return fmt.Sprintf("%dx%d-%s", c.Rows, c.Columns, walls)
+}
+
+// Rules returns the parts of the config the engine plays by.
+func (c GameStartConfig) Rules() Rules {
+	return Rules{
+		Rows:           c.Rows,
+		Columns:        c.Columns,
+		Pillars:        c.Pillars,
+		IsWalled:       c.IsWalled,
+		Scoring:        c.Scoring,
+		ScoreThreshold: c.ScoreThreshold,
+	}
+}
+
+// RunInfo describes this config for tagging scores.
+func (c GameStartConfig) RunInfo() internal.RunInfo {
+	mode := c.Mode
+	if mode == "" {
+		mode = internal.GameModeClassic
+	}
+
+	return internal.RunInfo{
+		Level: c.Level,
+		Mode:  mode,
+		Board: c.BoardName(),
+	}
 }
 
 func TickGame() tea.Cmd {
@@ -31,8 +161,27 @@ func TickGame() tea.Cmd {
 	}
 }
 
-func DebugGameConfig() GameStartConfig {
-	return GameStartConfig{
+// LevelGameConfig returns the config for the given level, falling back to
+// the default warm-up board for level 0.
+func LevelGameConfig(app *internal.App, level int) GameStartConfig {
+	switch level {
+	case 1:
+		return Level1GameConfig(app)
+	case 2:
+		return Level2GameConfig(app)
+	case 3:
+		return Level3GameConfig(app)
+	case 4:
+		return Level4GameConfig(app)
+	case 5:
+		return Level5GameConfig(app)
+	default:
+		return DefaultGameConfig(app)
+	}
+}
+
+func DebugGameConfig(app *internal.App) GameStartConfig {
+	return withApp(GameStartConfig{
 		Rows:           30,
 		Columns:        25,
 		Scoring:        10,
@@ -40,26 +189,29 @@ func DebugGameConfig() GameStartConfig {
 		IsDebugGrid:    true,
 		ScoreThreshold: 200,
 		FPS:            time.Millisecond * 250,
-		ScoreService:   internal.GetScoreService(),
-		SessionManager: internal.GetSessionManager(),
-	}
+	}, app)
 }
 
-func DefaultGameConfig() GameStartConfig {
-	return GameStartConfig{
+func DefaultGameConfig(app *internal.App) GameStartConfig {
+	config := withApp(GameStartConfig{
 		Rows:           30,
 		Columns:        25,
 		Scoring:        10,
 		IsWalled:       true,
+		Level:          warmUpLevel,
 		FPS:            time.Millisecond * 250,
 		ScoreThreshold: 20, //TODO MUST REMOVE
-		ScoreService:   internal.GetScoreService(),
-		SessionManager: internal.GetSessionManager(),
+	}, app)
+
+	if app != nil {
+		config.IsWalled = app.Settings.WarmUpWalls
 	}
+
+	return config
 }
 
-func Level1GameConfig() GameStartConfig {
-	return GameStartConfig{
+func Level1GameConfig(app *internal.App) GameStartConfig {
+	return withApp(GameStartConfig{
 		Rows:           35,
 		Columns:        25,
 		ScoreThreshold: 700,
@@ -67,13 +219,11 @@ func Level1GameConfig() GameStartConfig {
 		Pillars:        level1Pillars,
 		Level:          1,
 		FPS:            time.Millisecond * 200,
-		ScoreService:   internal.GetScoreService(),
-		SessionManager: internal.GetSessionManager(),
-	}
+	}, app)
 }
 
-func Level2GameConfig() GameStartConfig {
-	return GameStartConfig{
+func Level2GameConfig(app *internal.App) GameStartConfig {
+	return withApp(GameStartConfig{
 		Rows:           35,
 		Columns:        25,
 		IsWalled:       true,
@@ -82,13 +232,11 @@ func Level2GameConfig() GameStartConfig {
 		Level:          2,
 		FPS:            time.Millisecond * 200,
 		Pillars:        level2Pillars,
-		ScoreService:   internal.GetScoreService(),
-		SessionManager: internal.GetSessionManager(),
-	}
+	}, app)
 }
 
-func Level3GameConfig() GameStartConfig {
-	return GameStartConfig{
+func Level3GameConfig(app *internal.App) GameStartConfig {
+	return withApp(GameStartConfig{
 		Rows:           35,
 		Columns:        25,
 		ScoreThreshold: 3500,
@@ -96,13 +244,11 @@ func Level3GameConfig() GameStartConfig {
 		Level:          3,
 		FPS:            time.Millisecond * 150,
 		Pillars:        level3Pillars,
-		ScoreService:   internal.GetScoreService(),
-		SessionManager: internal.GetSessionManager(),
-	}
+	}, app)
 }
 
-func Level4GameConfig() GameStartConfig {
-	return GameStartConfig{
+func Level4GameConfig(app *internal.App) GameStartConfig {
+	return withApp(GameStartConfig{
 		Rows:           35,
 		Columns:        25,
 		ScoreThreshold: 5500,
@@ -111,13 +257,11 @@ func Level4GameConfig() GameStartConfig {
 		Scoring:        10,
 		Level:          4,
 		FPS:            time.Millisecond * 150,
-		ScoreService:   internal.GetScoreService(),
-		SessionManager: internal.GetSessionManager(),
-	}
+	}, app)
 }
 
-func Level5GameConfig() GameStartConfig {
-	return GameStartConfig{
+func Level5GameConfig(app *internal.App) GameStartConfig {
+	return withApp(GameStartConfig{
 		Rows:           35,
 		Columns:        25,
 		ScoreThreshold: 8000,
@@ -127,7 +271,5 @@ func Level5GameConfig() GameStartConfig {
 		IsFinalLevel:   true,
 		FPS:            time.Millisecond * 150,
 		Pillars:        level3Pillars,
-		ScoreService:   internal.GetScoreService(),
-		SessionManager: internal.GetSessionManager(),
-	}
+	}, app)
 }
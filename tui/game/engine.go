@@ -0,0 +1,281 @@
+package game
+
+import (
+	"math/rand/v2"
+
+	"github.com/the-Jinxist/golang_snake_game/internal"
+)
+
+// Rules are the parts of a level's config that decide how the snake moves,
+// dies and scores.
+type Rules struct {
+	Rows           int
+	Columns        int
+	Pillars        []Position
+	IsWalled       bool
+	Scoring        int
+	ScoreThreshold int
+}
+
+// Engine is the board state of one level and the rules that advance it. It
+// does no I/O: given the same seed and the same turns at the same ticks it
+// always ends in the same state, which is what lets a replay be checked.
+type Engine struct {
+	Snake []Position
+
+	Food          Food
+	Direction     Direction
+	Score         int
+	IsGameOver    bool
+	IsOutOfBounds bool
+	DeathCause    internal.DeathCause
+	DeathPosition Position
+	FoodEaten     int
+	Ticks         int
+	Seed          uint64
+	Inputs        []internal.ReplayInput
+	rules         Rules
+	startScore    int
+	source        *rand.PCG
+	rng           *rand.Rand
+}
+
+// StepResult reports what happened during a single move.
+type StepResult struct {
+	Moved   bool
+	Ate     bool
+	BigFish bool
+}
+
+// NewEngine places the snake in the middle of the board and the first food
+// drawn from seed.
+func NewEngine(rules Rules, seed uint64, startScore int) Engine {
+	source := rand.NewPCG(seed, seed)
+
+	e := Engine{
+		Snake: []Position{
+			{
+				X: rules.Rows / 2,
+				Y: rules.Columns / 2,
+			},
+		},
+		Direction:  Right,
+		Score:      startScore,
+		Seed:       seed,
+		rules:      rules,
+		startScore: startScore,
+		source:     source,
+		rng:        rand.New(source),
+	}
+
+	e.instantiateFood()
+	return e
+}
+
+// Replay returns what is needed to re-simulate this level so far.
+func (e *Engine) Replay(level int) internal.LevelReplay {
+	return internal.LevelReplay{
+		Level:      level,
+		Seed:       e.Seed,
+		StartScore: e.startScore,
+		Ticks:      e.Ticks,
+		Inputs:     append([]internal.ReplayInput(nil), e.Inputs...),
+		Open:       !e.rules.IsWalled,
+	}
+}
+
+// Turn points the snake in direction, unless that would reverse it onto
+// itself. Accepted turns are recorded for the replay.
+func (e *Engine) Turn(direction Direction) bool {
+	switch direction {
+	case Up, Down:
+		if e.Direction != Left && e.Direction != Right {
+			return false
+		}
+	case Left, Right:
+		if e.Direction != Up && e.Direction != Down {
+			return false
+		}
+	default:
+		return false
+	}
+
+	e.Direction = direction
+	e.Inputs = append(e.Inputs, internal.ReplayInput{Tick: e.Ticks, Direction: int(direction)})
+	return true
+}
+
+// Step moves the snake one cell. Nothing happens once the run is over or
+// the level's score threshold is reached.
+func (e *Engine) Step() StepResult {
+	var result StepResult
+
+	if e.IsGameOver || e.hasReachedLevelThreshold() {
+		return result
+	}
+
+	result.Moved = true
+	e.Ticks++
+
+	pos := e.directionToPosition(e.Direction)
+
+	currentSnakeHead := e.Snake[0]
+
+	movingToX := currentSnakeHead.X + pos.X
+	movingToY := currentSnakeHead.Y + pos.Y
+
+	//If snake is out of bounds
+	if e.hasHitWall(movingToX, movingToY) {
+		e.die(internal.DeathWall, movingToX, movingToY)
+	}
+
+	if e.isPillar(movingToX, movingToY) {
+		e.die(internal.DeathPillar, movingToX, movingToY)
+	}
+
+	// If snakes eats itself
+	if e.isSnake(movingToX, movingToY) {
+		e.die(internal.DeathSelf, movingToX, movingToY)
+	}
+
+	if e.IsGameOver {
+		return result
+	}
+
+	if e.isFood(movingToX, movingToY) {
+		result.Ate = true
+		result.BigFish = e.Food.BigFish
+
+		e.instantiateFood()
+		newSnakeHead := Position{
+			X: movingToX,
+			Y: movingToY,
+		}
+
+		e.Snake = append([]Position{newSnakeHead}, e.Snake...)
+
+		e.Score += e.rules.Scoring
+		e.FoodEaten++
+	}
+
+	//Snap snake back to the opp. side of stage if he goes out of bounds
+	if e.isOutOfBounds(movingToX, movingToY) ||
+		e.isOutOfBounds(currentSnakeHead.X, currentSnakeHead.Y) {
+
+		// e.IsOutOfBounds = true
+		if movingToX > e.rules.Rows-1 || currentSnakeHead.X > e.rules.Rows-1 {
+			movingToX = 0
+		}
+
+		if movingToX < 0 || currentSnakeHead.X < 0 {
+			movingToX = e.rules.Rows - 1
+		}
+
+		if movingToY > e.rules.Columns-1 || currentSnakeHead.Y > e.rules.Columns-1 {
+			movingToY = 0
+		}
+
+		if movingToY < 0 || currentSnakeHead.Y < 0 {
+			movingToY = e.rules.Columns - 1
+		}
+	}
+
+	newSnakeHead := Position{
+		X: movingToX,
+		Y: movingToY,
+	}
+
+	e.Snake = e.Snake[:len(e.Snake)-1]
+	e.Snake = append([]Position{newSnakeHead}, e.Snake...)
+
+	return result
+}
+
+func (e *Engine) isSnake(x int, y int) bool {
+	isSnake := false
+	for _, snakeBody := range e.Snake {
+		if snakeBody.X == x && snakeBody.Y == y {
+			isSnake = true
+		}
+	}
+
+	return isSnake
+}
+
+func (e *Engine) isSnakeHead(x int, y int) bool {
+	snakeHead := e.Snake[0]
+
+	return x == snakeHead.X && y == snakeHead.Y
+}
+
+func (e *Engine) isFood(x int, y int) bool {
+	food := e.Food
+	return x == food.X && y == food.Y
+}
+
+func (e *Engine) directionToPosition(direction Direction) Position {
+	position := Position{
+		Y: 0, X: 1,
+	}
+
+	switch direction {
+	case Up:
+		position = Position{Y: -1, X: 0}
+	case Down:
+		position = Position{Y: 1, X: 0}
+	case Left:
+		position = Position{Y: 0, X: -1}
+	}
+
+	return position
+}
+
+func (e *Engine) hasHitWall(x int, y int) bool {
+	return e.isOutOfBounds(x, y) && e.rules.IsWalled
+}
+
+func (e *Engine) isPillar(x, y int) bool {
+	for _, pos := range e.rules.Pillars {
+		if x == pos.X && y == pos.Y {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e *Engine) isOutOfBounds(x int, y int) bool {
+	return x > e.rules.Rows-1 || y > e.rules.Columns-1 || x < 0 || y < 0
+}
+
+func (e *Engine) hasReachedLevelThreshold() bool {
+	return e.Score == e.rules.ScoreThreshold
+}
+
+func (e *Engine) instantiateFood() {
+
+	rows := e.rules.Rows
+	columns := e.rules.Columns
+
+	randomX := e.rng.IntN(rows)
+	randomY := e.rng.IntN(columns)
+
+	for e.isSnake(randomX, randomY) || e.isPillar(randomX, randomY) || e.hasHitWall(randomX, randomY) {
+		randomX = e.rng.IntN(rows)
+		randomY = e.rng.IntN(columns)
+	}
+
+	e.Food = Food{
+		Position: Position{
+			X: randomX,
+			Y: randomY,
+		},
+		BigFish: e.rng.IntN(bigFishChance) == 0,
+	}
+}
+
+func (e *Engine) die(cause internal.DeathCause, x, y int) {
+	e.IsGameOver = true
+	e.DeathCause = cause
+	e.DeathPosition = Position{X: x, Y: y}
+}
@@ -0,0 +1,51 @@
+package game
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/the-Jinxist/golang_snake_game/internal"
+)
+
+const toastDuration = 3 * time.Second
+
+// emit reports a gameplay event to the achievement service and queues a toast
+// for anything it unlocked.
+func (g *GameModel) emit(kind internal.GameEventKind, bigFish bool) {
+	if g.Config.Achievements == nil {
+		return
+	}
+
+	session, err := g.Config.SessionManager.GetCurrentSession()
+	if err != nil {
+		return
+	}
+
+	unlocked, _ := g.Config.Achievements.Record(context.Background(), g.Config.ScoreService.GetCurrentUser(), internal.GameEvent{
+		Kind:    kind,
+		Session: session,
+		Level:   g.Config.Level,
+		Score:   g.Score,
+		BigFish: bigFish,
+		Elapsed: g.Elapsed,
+	})
+
+	if len(unlocked) > 0 {
+		g.toast = unlocked
+		g.toastUntil = time.Now().Add(toastDuration)
+	}
+}
+
+func (g *GameModel) toastView() string {
+	if len(g.toast) == 0 || time.Now().After(g.toastUntil) {
+		return ""
+	}
+
+	lines := make([]string, 0, len(g.toast))
+	for _, achievement := range g.toast {
+		lines = append(lines, "🏆 "+achievement.Title+"\n"+achievement.Description)
+	}
+
+	return g.styles.toast.Render("ACHIEVEMENT UNLOCKED\n\n" + strings.Join(lines, "\n\n"))
+}
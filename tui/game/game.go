@@ -3,15 +3,18 @@ package game
 import (
 	"context"
 	"fmt"
-	"log"
-	"math/rand/v2"
 	"strings"
 	"time"
 
 	"github.com/Broderick-Westrope/charmutils"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/the-Jinxist/golang_snake_game/internal"
+	"github.com/the-Jinxist/golang_snake_game/tui/keys"
+	"github.com/the-Jinxist/golang_snake_game/tui/skin"
 	"github.com/the-Jinxist/golang_snake_game/tui/views"
 	"github.com/the-Jinxist/golang_snake_game/utils"
 )
@@ -38,131 +41,78 @@ type Food struct {
 }
 
 type GameModel struct {
+	Engine
 	Config GameStartConfig
-	Snake  []Position
-
-	Food          Food
-	Direction     Direction
-	Score         int
-	IsGameOver    bool
-	IsOutOfBounds bool
-	spinner       spinner.Model
-	rng           *rand.Rand
-	isPaused      bool
-}
-
-func InitalGameModel(gameConfig GameStartConfig) *GameModel {
-	source := rand.NewPCG(uint64(time.Now().Unix()), uint64(time.Now().UnixMicro()))
-
-	s := spinner.New()
-	s.Spinner = spinner.Dot
 
-	currentScore, err := gameConfig.ScoreService.GetCurrentScore(context.Background())
-	if err != nil {
-		log.Fatalf("Failed to get current score: %s", err)
-	}
-
-	gameMod := &GameModel{
-		Snake: []Position{
-			{
-				X: gameConfig.Rows / 2,
-				Y: gameConfig.Columns / 2,
-			},
-		},
-		rng:       rand.New(source),
-		Config:    gameConfig,
-		Direction: Right,
-		Score:     currentScore,
-		spinner:   s,
-	}
-
-	return gameMod
+	Elapsed     time.Duration
+	spinner     spinner.Model
+	isPaused    bool
+	pauseCursor int
+	saveErr     error
+	// isSaving is set while a command saves the run; keys wait for it.
+	isSaving      bool
+	storageErr    error
+	deathErr      error
+	scoreWriter   *internal.ScoreWriter
+	statsReported bool
+	toast         []internal.Achievement
+	toastUntil    time.Time
+	// ringing is set while the bell is part of the view.
+	ringing bool
+
+	isEnteringName bool
+	nameInput      textinput.Model
+	nameErr        error
+
+	styles styles
 }
 
-func (g *GameModel) isSnake(x int, y int) bool {
-	isSnake := false
-	for _, snakeBody := range g.Snake {
-		if snakeBody.X == x && snakeBody.Y == y {
-			isSnake = true
-		}
-	}
+var pauseChoices = []string{"Resume", "Save & quit", "Back to menu"}
 
-	return isSnake
-}
+// bigFishChance is the one-in-N chance that newly spawned food is a big fish.
+const bigFishChance = 8
 
-func (g *GameModel) isSnakeHead(x int, y int) bool {
-	snakeHead := g.Snake[0]
+// levelUpDelay is how long "We're going up!" shows before the next level.
+const levelUpDelay = 2 * time.Second
 
-	return x == snakeHead.X && y == snakeHead.Y
-}
+func InitalGameModel(gameConfig GameStartConfig) *GameModel {
+	seed := uint64(time.Now().UnixNano())
 
-func (g *GameModel) isFood(x int, y int) bool {
-	food := g.Food
-	return x == food.X && y == food.Y
-}
+	s := spinner.New()
+	s.Spinner = spinner.Dot
 
-func (g *GameModel) directionToPosition(direction Direction) Position {
-	position := Position{
-		Y: 0, X: 1,
-	}
+	// If the score store is failing, play the level without it rather than
+	// taking the whole program down; the view warns that nothing is saved.
+	currentScore, storageErr := gameConfig.startLevel(seed)
+	if storageErr != nil {
+		gameConfig = gameConfig.withoutPersistence(storageErr)
 
-	switch direction {
-	case Up:
-		position = Position{Y: -1, X: 0}
-	case Down:
-		position = Position{Y: 1, X: 0}
-	case Left:
-		position = Position{Y: 0, X: -1}
+		// In-memory services do not fail.
+		currentScore, _ = gameConfig.startLevel(seed)
+	} else if gameConfig.app != nil {
+		storageErr = gameConfig.app.StorageErr
 	}
 
-	return position
-}
-
-func (g *GameModel) hasHitWall(x int, y int) bool {
-	return g.isOutOfBounds(x, y) && g.Config.IsWalled
-}
-
-func (g *GameModel) isPillar(x, y int) bool {
-	for _, pos := range g.Config.Pillars {
-		if x == pos.X && y == pos.Y {
-			return true
-		}
+	gameMod := &GameModel{
+		Engine:      NewEngine(gameConfig.Rules(), seed, currentScore.Value),
+		Config:      gameConfig,
+		Elapsed:     currentScore.Duration,
+		spinner:     s,
+		storageErr:  storageErr,
+		scoreWriter: internal.NewScoreWriter(gameConfig.ScoreService),
+		styles:      newStyles(gameConfig.Theme),
 	}
 
-	return false
-}
+	gameMod.emit(internal.EventLevelStarted, false)
 
-func (g *GameModel) isOutOfBounds(x int, y int) bool {
-	return x > g.Config.Rows-1 || y > g.Config.Columns-1 || x < 0 || y < 0
+	return gameMod
 }
 
 // Init implements tea.Model.
 func (g *GameModel) Init() tea.Cmd {
-	g.instantiateFood()
 	return tea.Batch(g.Tick())
 }
 
-func (g *GameModel) instantiateFood() {
-
-	rows := g.Config.Rows
-	columns := g.Config.Columns
-
-	randomX := g.rng.IntN(rows)
-	randomY := g.rng.IntN(columns)
-
-	for g.isSnake(randomX, randomY) || g.isPillar(randomX, randomY) || g.hasHitWall(randomX, randomY) {
-		randomX = g.rng.IntN(rows)
-		randomY = g.rng.IntN(columns)
-	}
-
-	g.Food = Food{
-		Position: Position{
-			X: randomX,
-			Y: randomY,
-		},
-	}
-}
-
 // Update implements tea.Model.
 func (g *GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
@@ -172,56 +122,50 @@ func (g *GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		input := msg.String()
-
-		if g.hasReachedLevelThreshold() {
+		if g.hasReachedLevelThreshold() || g.isSaving {
 			return g, nil
 		}
 
 		if g.IsGameOver {
-			g.Config.SessionManager.DestroyCurrentSession()
-			if utils.KeyMatchesInput(input, utils.Esc, utils.Space) {
+			if g.isEnteringName {
+				return g.updateNameEntry(msg)
+			}
+
+			if key.Matches(msg, g.Config.Keys.Back, g.Config.Keys.Select) {
 				return g, tea.Batch(views.ClearScreen(), views.SwitchModeCmd(views.ModeMenu))
 			}
 
 			return g, nil
 		}
 
-		if utils.KeyMatchesInput(input, utils.Space) {
+		if key.Matches(msg, g.Config.Keys.Pause) {
 			g.isPaused = !g.isPaused
+			g.pauseCursor = 0
+			g.saveErr = nil
+			if g.isPaused {
+				g.emit(internal.EventPaused, false)
+			}
+			return g, nil
 		}
 
 		if g.isPaused {
-
-			if utils.KeyMatchesInput(input, utils.Esc) {
-				return g, tea.Batch(views.SwitchModeCmd(views.ModeMenu))
-			}
-
-			return g, nil
+			return g.updatePauseMenu(msg)
 		}
 
-		if utils.KeyMatchesInput(input, utils.KeyUp) {
-			if g.Direction == Left || g.Direction == Right {
-				g.Direction = Up
-			}
+		if key.Matches(msg, g.Config.Keys.Up) {
+			g.Turn(Up)
 		}
 
-		if utils.KeyMatchesInput(input, utils.KeyRight) {
-			if g.Direction == Up || g.Direction == Down {
-				g.Direction = Right
-			}
+		if key.Matches(msg, g.Config.Keys.Right) {
+			g.Turn(Right)
 		}
 
-		if utils.KeyMatchesInput(input, utils.KeyDown) {
-			if g.Direction == Left || g.Direction == Right {
-				g.Direction = Down
-			}
+		if key.Matches(msg, g.Config.Keys.Down) {
+			g.Turn(Down)
 		}
 
-		if utils.KeyMatchesInput(input, utils.KeyLeft) {
-			if g.Direction == Up || g.Direction == Down {
-				g.Direction = Left
-			}
+		if key.Matches(msg, g.Config.Keys.Left) {
+			g.Turn(Left)
 		}
 
 		return g, nil
@@ -229,19 +173,118 @@ func (g *GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case Tick:
 
 		if !g.isPaused && !g.IsGameOver {
-			g.moveSnake()
+			g.Elapsed += g.Config.FPS
+			bell := g.moveSnake()
+
+			g.emit(internal.EventTick, false)
+
+			if g.IsGameOver {
+				g.saveScore()
+				g.emit(internal.EventGameOver, false)
+			}
+
+			if g.IsGameOver || g.hasReachedLevelThreshold() {
+				g.reportStats()
+			}
+
+			if g.IsGameOver {
+				g.isSaving = true
+				return g, tea.Batch(bell, g.endRun())
+			}
+
+			return g, tea.Batch(bell, g.Tick())
 		}
 
 		return g, tea.Batch(g.Tick())
+
+	case runEndedMsg:
+		g.isSaving = false
+		g.deathErr = msg.err
+		if msg.qualifies {
+			return g, g.startNameEntry()
+		}
+
+		// A run entering a name is finished once the name is in; any other
+		// run ends here rather than on the next key press.
+		g.FinishSession()
+		return g, nil
+
+	case nameSavedMsg:
+		return g.nameSaved(msg)
+
+	case bellRungMsg:
+		g.ringing = false
+		return g, nil
+
+	case gameSavedMsg:
+		g.isSaving = false
+		if msg.err != nil {
+			g.saveErr = msg.err
+			return g, nil
+		}
+
+		return g, tea.Quit
+
 	default:
-		return g, tea.Batch(g.Tick())
+		// Ticks are only started by Init and by the previous tick, so a
+		// stray message never speeds the snake up.
+		return g, nil
+
+	}
+
+}
+
+func (g *GameModel) updatePauseMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.Matches(msg, g.Config.Keys.Back) {
+		return g, g.abandonRun()
+	}
+
+	if key.Matches(msg, g.Config.Keys.Up) && g.pauseCursor > 0 {
+		g.pauseCursor--
+	}
 
+	if key.Matches(msg, g.Config.Keys.Down) && g.pauseCursor < len(pauseChoices)-1 {
+		g.pauseCursor++
 	}
 
+	if key.Matches(msg, g.Config.Keys.Select) {
+		switch g.pauseCursor {
+		case 0:
+			g.isPaused = false
+			g.saveErr = nil
+		case 1:
+			return g, g.saveAndQuit()
+		case 2:
+			return g, g.abandonRun()
+		}
+	}
+
+	return g, nil
 }
 
-func (g *GameModel) hasReachedLevelThreshold() bool {
-	return g.Score == g.Config.ScoreThreshold
+// reportStats sends the level's end-of-game stats to the stats store once.
+func (g *GameModel) reportStats() {
+	if g.statsReported || g.Config.StatsStore == nil {
+		return
+	}
+
+	g.statsReported = true
+
+	session, err := g.Config.SessionManager.GetCurrentSession()
+	if err != nil {
+		return
+	}
+
+	g.Config.StatsStore.RecordGame(context.Background(), internal.GameStats{
+		User:        g.Config.ScoreService.GetCurrentUser(),
+		Session:     session,
+		Level:       g.Config.Level,
+		Score:       g.Score,
+		FoodEaten:   g.FoodEaten,
+		SnakeLength: len(g.Snake),
+		Duration:    g.Elapsed,
+		DeathCause:  g.DeathCause,
+	})
 }
 
 func (g *GameModel) Tick() tea.Cmd {
@@ -251,17 +294,16 @@ func (g *GameModel) Tick() tea.Cmd {
 	}
 
 	if g.hasReachedLevelThreshold() {
-
-		if g.Config.Level == 5 {
-			fmt.Print("\033[H\033[2J")
-			return tea.Batch(views.SwitchModeCmd(views.ModeGameCompleted))
+		// The next level starts from the score saved for this one, so the
+		// switch waits for queued writes to land.
+		if g.Config.Level == finalLevel {
+			return tea.Sequence(g.flushScoreCmd(), views.SwitchModeCmd(views.ModeGameCompleted))
 		}
 
-		time.Sleep(2 * time.Second)
 		nextLevel := views.NextLevelModeFromCurrent(g.Config.Level)
-
-		fmt.Print("\033[H\033[2J")
-		return tea.Batch(views.SwitchModeCmd(nextLevel))
+		return tea.Sequence(g.flushScoreCmd(), tea.Tick(levelUpDelay, func(time.Time) tea.Msg {
+			return views.SwitchModeMsg{Target: nextLevel}
+		}))
 	}
 
 	return tea.Tick(g.Config.FPS, func(t time.Time) tea.Msg {
@@ -269,94 +311,155 @@ func (g *GameModel) Tick() tea.Cmd {
 	})
 }
 
-func (g *GameModel) moveSnake() {
+// moveSnake advances the engine one move and reports what the move did. It
+// returns the bell to ring for eating or dying, if any.
+func (g *GameModel) moveSnake() tea.Cmd {
 
 	if g.Config.IsDebugGrid {
-		return
+		return nil
 	}
 
-	pos := g.directionToPosition(g.Direction)
+	result := g.Step()
+	if result.Ate {
+		g.saveScore()
+		g.emit(internal.EventFoodEaten, result.BigFish)
+	}
 
-	currentSnakeHead := g.Snake[0]
+	if result.Ate || g.IsGameOver {
+		return g.bell()
+	}
 
-	movingToX := currentSnakeHead.X + pos.X
-	movingToY := currentSnakeHead.Y + pos.Y
+	return nil
+}
 
-	//If snake is out of bounds
-	if g.hasHitWall(movingToX, movingToY) {
-		g.IsGameOver = true
-	}
+// bellDuration is how long the bell stays in the view: long enough for the
+// renderer, which draws up to 60 frames a second, to write it once, and
+// shorter than the fastest tick so the next bell is a change again.
+const bellDuration = 50 * time.Millisecond
 
-	if g.isPillar(movingToX, movingToY) {
-		g.IsGameOver = true
-	}
+// bellRungMsg takes the bell back out of the view.
+type bellRungMsg struct{}
 
-	// If snakes eats itself
-	if g.isSnake(movingToX, movingToY) {
-		g.IsGameOver = true
+// bell rings the terminal bell when sound is turned on in the settings. The
+// bell character goes out in the program's next frame, so it never races
+// the renderer for the terminal.
+func (g *GameModel) bell() tea.Cmd {
+	if !g.Config.Sound {
+		return nil
 	}
 
-	if g.IsGameOver || g.hasReachedLevelThreshold() {
-		return
-	}
+	g.ringing = true
+	return tea.Tick(bellDuration, func(time.Time) tea.Msg {
+		return bellRungMsg{}
+	})
+}
 
-	if g.isFood(movingToX, movingToY) {
-		g.instantiateFood()
-		newSnakeHead := Position{
-			X: movingToX,
-			Y: movingToY,
-		}
+// runEndedMsg reports that a finished run's score and death were saved.
+type runEndedMsg struct {
+	qualifies bool
+	err       error
+}
+
+// endRun saves the end of the run and checks whether it made the
+// leaderboard. Both can go through a leaderboard server, so they run as a
+// command rather than holding up the screen.
+func (g *GameModel) endRun() tea.Cmd {
+	scores := g.Config.ScoreService
+	cause, position := g.DeathCause, g.DeathPosition
+
+	return func() tea.Msg {
+		g.FlushScore()
+		err := scores.SetCurrentDeath(context.Background(), cause, position.X, position.Y)
+		return runEndedMsg{qualifies: g.qualifiesForHighScore(), err: err}
+	}
+}
 
-		g.Snake = append([]Position{newSnakeHead}, g.Snake...)
+// abandonRun goes back to the menu, ending the run's session once its queued
+// score writes have landed.
+func (g *GameModel) abandonRun() tea.Cmd {
+	g.isSaving = true
+	return tea.Sequence(g.flushScoreCmd(), func() tea.Msg {
+		g.Config.SessionManager.DestroyCurrentSession()
+		return views.SwitchModeMsg{Target: views.ModeMenu}
+	})
+}
 
-		g.increaseScore()
+// gameSavedMsg reports how saving the board on the way out went.
+type gameSavedMsg struct {
+	err error
+}
 
+// saveAndQuit saves the board from a command, as the score it flushes first
+// can go through a leaderboard server, and quits once it is saved. The game
+// stays paused, showing the error, if it could not be.
+func (g *GameModel) saveAndQuit() tea.Cmd {
+	g.isPaused, g.isSaving = true, true
+	return func() tea.Msg {
+		return gameSavedMsg{err: g.Save()}
 	}
+}
 
-	//Snap snake back to the opp. side of stage if he goes out of bounds
-	if g.isOutOfBounds(movingToX, movingToY) ||
-		g.isOutOfBounds(currentSnakeHead.X, currentSnakeHead.Y) {
+// Quit leaves the program, saving a game worth continuing first. When that
+// save has already failed, quitting again leaves without it. Keys wait while
+// the run is being saved, and so does quitting.
+func (g *GameModel) Quit() tea.Cmd {
+	if g.isSaving {
+		return nil
+	}
 
-		// g.IsOutOfBounds = true
-		if movingToX > g.Config.Rows-1 || currentSnakeHead.X > g.Config.Rows-1 {
-			movingToX = 0
-		}
+	if g.CanSave() && g.saveErr == nil {
+		return g.saveAndQuit()
+	}
 
-		if movingToX < 0 || currentSnakeHead.X < 0 {
-			movingToX = g.Config.Rows - 1
-		}
+	g.isSaving = true
+	gameOver := g.IsGameOver
+	return func() tea.Msg {
+		g.FlushScore()
 
-		if movingToY > g.Config.Columns-1 || currentSnakeHead.Y > g.Config.Columns-1 {
-			movingToY = 0
+		// Quitting while entering a high-score name skips the name.
+		if gameOver {
+			g.FinishSession()
 		}
 
-		if movingToY < 0 || currentSnakeHead.Y < 0 {
-			movingToY = g.Config.Columns - 1
-		}
+		return tea.Quit()
 	}
+}
 
-	newSnakeHead := Position{
-		X: movingToX,
-		Y: movingToY,
+// flushScoreCmd waits for queued score writes off the UI goroutine.
+func (g *GameModel) flushScoreCmd() tea.Cmd {
+	return func() tea.Msg {
+		g.FlushScore()
+		return nil
 	}
-
-	g.Snake = g.Snake[:len(g.Snake)-1]
-	g.Snake = append([]Position{newSnakeHead}, g.Snake...)
 }
 
-func (g *GameModel) increaseScore() {
-	g.Score += g.Config.Scoring
+// FinishSession records the run as finished. Everything that reads the
+// current session at game over must run first, as the session is cleared.
+func (g *GameModel) FinishSession() {
+	g.Config.SessionManager.FinishCurrentSession()
+}
 
-	go func() {
-		g.Config.ScoreService.SetCurrentScore(context.Background(), g.Score)
-	}()
+// saveScore queues the score together with the replay that produced it, so
+// the two always describe the same moment of the run.
+func (g *GameModel) saveScore() {
+	g.scoreWriter.Write(internal.ScoreUpdate{
+		Value:  g.Score,
+		Played: g.Elapsed,
+		Replay: g.Replay(g.Config.Level),
+	})
+}
 
+// FlushScore waits for queued score writes to land. It must be called before
+// the session changes, since writes go to whichever session is current.
+func (g *GameModel) FlushScore() error {
+	return g.scoreWriter.Flush()
 }
 
 // View implements tea.Model.
 func (g *GameModel) View() string {
 
-	cellStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#CCCCCC"))
+	palette := g.Config.Theme
+	segments := g.segmentIndexes()
 	var output string
 	for i := range g.Config.Columns {
 		for j := range g.Config.Rows {
@@ -370,19 +473,19 @@ func (g *GameModel) View() string {
 				continue
 			}
 
-			if g.isSnake(j, i) {
-				if g.isSnakeHead(j, i) {
-					output += SnakeHeadFromDirection(g.Direction)
+			if index, ok := segments[Position{X: j, Y: i}]; ok {
+				if index == 0 {
+					output += g.Config.Skin.Paint(palette, SnakeHeadFromDirection(palette, g.Direction), 0, len(g.Snake))
 				} else {
-					output += cellStyle.Render(FilledCell)
+					output += g.Config.Skin.Segment(palette, g.segmentShape(index), index, len(g.Snake))
 				}
 
 			} else if g.isFood(j, i) {
-				output += FoodCell()
+				output += FoodCell(palette, g.Food.BigFish)
 			} else if g.isPillar(j, i) {
 				output += PillarCell
 			} else {
-				output += cellStyle.Render(EmptyCell)
+				output += g.styles.board.Render(palette.EmptyCell)
 			}
 
 		}
@@ -396,11 +499,16 @@ func (g *GameModel) View() string {
 	if g.isPaused {
 		output += lipgloss.NewStyle().
 			AlignHorizontal(lipgloss.Center).
-			Render(fmt.Sprintf("[ PAUSED ]. Your score: %d/%d. Press SPACE to resume! Press ESC to back to menu", g.Score, g.Config.ScoreThreshold))
+			Render(fmt.Sprintf("[ PAUSED ]. Your score: %d/%d. Press %s to resume! Press %s to back to menu", g.Score, g.Config.ScoreThreshold, keys.Label(g.Config.Keys.Pause), keys.Label(g.Config.Keys.Back)))
+		output, _ = charmutils.OverlayCenter(output, g.pauseMenuView(), false)
 	} else {
 		output += lipgloss.NewStyle().
 			AlignHorizontal(lipgloss.Center).
-			Render(fmt.Sprintf("Your score: %d/%d. Press SPACE to pause!", g.Score, g.Config.ScoreThreshold))
+			Render(fmt.Sprintf("Your score: %d/%d. Press %s to pause!", g.Score, g.Config.ScoreThreshold, keys.Label(g.Config.Keys.Pause)))
+	}
+
+	if warning := g.storageWarning(); warning != "" {
+		output += "\n" + g.styles.error.Render(warning)
 	}
 
 	if g.hasReachedLevelThreshold() {
@@ -413,27 +521,104 @@ func (g *GameModel) View() string {
 
 	}
 
+	if toast := g.toastView(); toast != "" && !g.IsGameOver {
+		output, _ = charmutils.OverlayCenter(output, toast, false)
+	}
+
 	if g.IsGameOver {
-		gameOverMessage := gameOverMsg
+		gameOverMessage := palette.Banner(gameOverMsg)
 		gameOverMessage += "\n"
-		gameOverMessage += lipgloss.NewStyle().
-			AlignHorizontal(lipgloss.Center).
-			Render(fmt.Sprintf("Your final score is %d/%d\nPress SPACE to go back to menu", g.Score, g.Config.ScoreThreshold))
+		if g.isEnteringName {
+			gameOverMessage += lipgloss.NewStyle().
+				AlignHorizontal(lipgloss.Center).
+				Render(g.nameEntryView())
+		} else {
+			gameOverMessage += lipgloss.NewStyle().
+				AlignHorizontal(lipgloss.Center).
+				Render(fmt.Sprintf("%s\nYour final score is %d/%d%s\nPress %s to go back to menu", g.deathMessage(), g.Score, g.Config.ScoreThreshold, g.unlockedSkinsMessage(), keys.Label(g.Config.Keys.Select)))
+		}
 		output, _ = charmutils.OverlayCenter(output, gameOverMessage, false)
 	}
 
-	levelIndicator := generateLevelIndicator(g.Config.Level)
+	output = g.generateLevelIndicator() + output
+
+	if g.Config.ShowHelp {
+		output += "\n" + g.styles.help.Render(generateHelpString(g.Config.Keys))
+	}
+
+	if g.ringing {
+		output += "\a"
+	}
+
+	return output
+}
+
+// unlockedSkinsMessage announces skins the run's score has unlocked. Guests
+// cannot pick a skin, so they are not told about them.
+func (g *GameModel) unlockedSkinsMessage() string {
+	unlocked := skin.UnlockedBetween(g.Config.Best, g.Score)
+	if len(unlocked) == 0 || g.Config.Guest {
+		return ""
+	}
+
+	return fmt.Sprintf("\nNew skin unlocked: %s! Pick it in Settings", strings.Join(unlocked, ", "))
+}
+
+// storageWarning explains why the score is not being saved, if it is not.
+func (g *GameModel) storageWarning() string {
+	if g.storageErr != nil {
+		return fmt.Sprintf("Scores are not being saved: %s", g.storageErr)
+	}
+
+	if g.deathErr != nil {
+		return fmt.Sprintf("How the run ended could not be saved: %s", g.deathErr)
+	}
+
+	if err := g.scoreWriter.Err(); err != nil {
+		return fmt.Sprintf("Your score could not be saved: %s", err)
+	}
+
+	return ""
+}
+
+func (g *GameModel) deathMessage() string {
+	position := fmt.Sprintf("%d,%d", g.DeathPosition.X, g.DeathPosition.Y)
+
+	switch g.DeathCause {
+	case internal.DeathWall:
+		return "Hit the wall at " + position
+	case internal.DeathPillar:
+		return "Hit a pillar at " + position
+	case internal.DeathSelf:
+		return "Ran into yourself at " + position
+	default:
+		return ""
+	}
+}
+
+func (g *GameModel) pauseMenuView() string {
+	menu := "[ PAUSED ]\n"
+	for index, value := range pauseChoices {
+		prefix := "  "
+		if index == g.pauseCursor {
+			prefix = g.styles.active.Render("> ")
+		}
 
-	help := generateHelpString()
-	help = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#444745")).
-		Render(help)
+		menu += fmt.Sprintf("\n%s%s", prefix, value)
+	}
+
+	if g.saveErr != nil {
+		menu += "\n\n" + g.styles.error.Render(fmt.Sprintf("Could not save game: %s. Press %s again to quit without saving", g.saveErr, keys.Label(g.Config.Keys.Quit)))
+	}
 
-	return levelIndicator + output + "\n" + help
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		Padding(1, 3).
+		Render(menu)
 }
 
-func generateHelpString() string {
-	help := "\n[INSTRUCTIONS]:\n · -> or D to move right\n · <- or A to move left\n · ↑ or W to move up\n · ↓ or S to move down"
+func generateHelpString(keyMap keys.KeyMap) string {
+	help := keys.Instructions(keyMap.Right, keyMap.Left, keyMap.Up, keyMap.Down, keyMap.Pause)
 
 	if utils.IsWindowsMachine() {
 		help = strings.ReplaceAll(help, "\n", " | ")
@@ -442,14 +627,10 @@ func generateHelpString() string {
 	return help
 }
 
-func generateLevelIndicator(level int) string {
-	lvlString := fmt.Sprintf("Level %d", level)
+func (g *GameModel) generateLevelIndicator() string {
+	lvlString := fmt.Sprintf("Level %d", g.Config.Level)
 	if !utils.IsWindowsMachine() {
-		lvlString = lipgloss.NewStyle().
-			Align(lipgloss.Center).
-			Padding(1).
-			Background(lipgloss.Color("#3297a8")).
-			Render(lvlString)
+		lvlString = g.styles.level.Render(lvlString)
 	}
 
 	return lvlString + "\n"
@@ -3,20 +3,26 @@ package game
 import (
 	"fmt"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/the-Jinxist/golang_snake_game/tui/keys"
+	"github.com/the-Jinxist/golang_snake_game/tui/theme"
 	"github.com/the-Jinxist/golang_snake_game/tui/views"
-	"github.com/the-Jinxist/golang_snake_game/utils"
 )
 
 var _ tea.Model = &GameCompleted{}
 
 type GameCompleted struct {
 	Score int
+	Keys  keys.KeyMap
+	Theme theme.Theme
 }
 
-func NewGameCompletedModel(score int) *GameCompleted {
+func NewGameCompletedModel(score int, keyMap keys.KeyMap, palette theme.Theme) *GameCompleted {
 	return &GameCompleted{
 		Score: score,
+		Keys:  keyMap,
+		Theme: palette,
 	}
 }
 
@@ -29,9 +35,7 @@ func (g *GameCompleted) Init() tea.Cmd {
 func (g *GameCompleted) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		input := msg.String()
-
-		if utils.KeyMatchesInput(input, utils.Esc, utils.Space) {
+		if key.Matches(msg, g.Keys.Back, g.Keys.Select) {
 			return g, tea.Batch(views.ClearScreen(), views.SwitchModeCmd(views.ModeMenu))
 		}
 	default:
@@ -45,9 +49,9 @@ func (g *GameCompleted) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (g *GameCompleted) View() string {
 	gameCompletedMsg := "\nImpossible! You are officially a"
 	gameCompletedMsg += "\n"
-	gameCompletedMsg += superSnakeMsg
+	gameCompletedMsg += g.Theme.Banner(superSnakeMsg)
 	gameCompletedMsg += "\n"
-	gameCompletedMsg += fmt.Sprintf("Your final score is %d\nPress SPACE to go back to menu", g.Score)
+	gameCompletedMsg += fmt.Sprintf("Your final score is %d\nPress %s to go back to menu", g.Score, keys.Label(g.Keys.Select))
 
 	return gameCompletedMsg
 }
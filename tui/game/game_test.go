@@ -0,0 +1,299 @@
+package game_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/the-Jinxist/golang_snake_game/internal"
+	"github.com/the-Jinxist/golang_snake_game/tui/game"
+)
+
+// guestApp returns an app on in-memory services with the default settings.
+func guestApp(player string) (*internal.App, *internal.InMemeorySessiomManagerImpl) {
+	sessions := &internal.InMemeorySessiomManagerImpl{}
+	sessions.SetUser(player)
+
+	return &internal.App{
+		Guest:    true,
+		Sessions: sessions,
+		Scores:   internal.NewInMemoryScoreService(player, sessions),
+		Settings: internal.DefaultSettings(),
+	}, sessions
+}
+
+// startGame restores a warm-up game on score with the snake in the middle
+// heading right and the food out of its way, so every run is the same.
+func startGame(t *testing.T, app *internal.App, score int) *game.GameModel {
+	t.Helper()
+
+	rng, err := rand.NewPCG(1, 1).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	session, err := app.Sessions.GetCurrentSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := game.RestoreGameModel(game.LevelGameConfig(app, 0), game.SavedGame{
+		Session:    session,
+		Snake:      []game.Position{{X: 15, Y: 12}},
+		Direction:  game.Right,
+		Food:       game.Food{Position: game.Position{X: 0, Y: 0}},
+		Score:      score,
+		StartScore: score,
+		RNG:        rng,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return g
+}
+
+// die runs the snake into the wall ahead and plays the end of the run
+// through.
+func die(t *testing.T, g *game.GameModel) {
+	t.Helper()
+
+	var cmd tea.Cmd
+	for moves := 0; !g.IsGameOver; moves++ {
+		if moves > 100 {
+			t.Fatal("snake never died")
+		}
+
+		_, cmd = g.Update(game.Tick{})
+	}
+
+	g.Update(cmd())
+}
+
+// board fills the leaderboard with five runs of value each.
+func board(t *testing.T, app *internal.App, value int) {
+	t.Helper()
+
+	var scores []internal.Score
+	for _, session := range []string{"a", "b", "c", "d", "e"} {
+		scores = append(scores, internal.Score{User: "bob", Value: value, Session: session})
+	}
+
+	if _, err := app.Scores.ImportScores(context.Background(), scores); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGameOver(t *testing.T) {
+	tests := []struct {
+		name      string
+		keys      []tea.KeyMsg
+		wantDeath internal.DeathCause
+		wantX     int
+		wantY     int
+	}{
+		{name: "straight into the right wall", wantDeath: internal.DeathWall, wantX: 30, wantY: 12},
+		{name: "up into the top wall", keys: []tea.KeyMsg{{Type: tea.KeyUp}}, wantDeath: internal.DeathWall, wantX: 15, wantY: -1},
+		{name: "down into the bottom wall", keys: []tea.KeyMsg{{Type: tea.KeyDown}}, wantDeath: internal.DeathWall, wantX: 15, wantY: 25},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			app, sessions := guestApp("alice")
+			g := startGame(t, app, 0)
+
+			for _, msg := range test.keys {
+				g.Update(msg)
+			}
+
+			die(t, g)
+
+			played := sessions.Sessions()
+			if len(played) != 1 || played[0].State != internal.SessionFinished {
+				t.Fatalf("sessions = %+v, want one finished session", played)
+			}
+
+			scores, err := app.Scores.QueryScores(context.Background(), internal.ScoreQuery{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(scores) != 1 {
+				t.Fatalf("%d scores recorded, want 1", len(scores))
+			}
+
+			score := scores[0]
+			if score.DeathCause != test.wantDeath || score.DeathX != test.wantX || score.DeathY != test.wantY {
+				t.Fatalf("death = %s at %d,%d, want %s at %d,%d", score.DeathCause, score.DeathX, score.DeathY, test.wantDeath, test.wantX, test.wantY)
+			}
+		})
+	}
+}
+
+func TestHighScoreQualifies(t *testing.T) {
+	tests := []struct {
+		name  string
+		score int
+		// board is what the five runs already on the leaderboard scored, or
+		// 0 for an empty board.
+		board int
+		want  bool
+	}{
+		{name: "empty board", score: 30, want: true},
+		{name: "nothing scored", score: 0, want: false},
+		{name: "beats the board", score: 30, board: 20, want: true},
+		{name: "below the board", score: 30, board: 40, want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			app, sessions := guestApp("alice")
+			if test.board > 0 {
+				board(t, app, test.board)
+			}
+
+			g := startGame(t, app, test.score)
+			die(t, g)
+
+			if g.IsCapturingInput() != test.want {
+				t.Fatalf("entering a name = %t, want %t", g.IsCapturingInput(), test.want)
+			}
+
+			played := sessions.Sessions()
+			finished := played[len(played)-1].State == internal.SessionFinished
+			if finished == test.want {
+				t.Fatalf("session finished = %t, want it finished only once no name is asked for", finished)
+			}
+		})
+	}
+}
+
+func TestNameEntry(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []tea.KeyMsg
+		want string
+	}{
+		{name: "keep the player's name", keys: []tea.KeyMsg{{Type: tea.KeyEnter}}, want: "alice"},
+		{
+			name: "type a name",
+			keys: append(
+				slices.Repeat([]tea.KeyMsg{{Type: tea.KeyBackspace}}, len("alice")),
+				tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("ZED")},
+				tea.KeyMsg{Type: tea.KeyEnter},
+			),
+			want: "ZED",
+		},
+		{name: "skip", keys: []tea.KeyMsg{{Type: tea.KeyEsc}}, want: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			app, sessions := guestApp("alice")
+			g := startGame(t, app, 30)
+			die(t, g)
+
+			if view := g.View(); !strings.Contains(view, "NEW HIGH SCORE!") || !strings.Contains(view, "Press ENTER to save or ESC to skip") {
+				t.Fatalf("view does not ask for a name:\n%s", view)
+			}
+
+			for _, msg := range test.keys {
+				_, cmd := g.Update(msg)
+
+				// The name is saved by a command that reports back.
+				if msg.Type == tea.KeyEnter {
+					g.Update(cmd())
+				}
+			}
+
+			if g.IsCapturingInput() {
+				t.Fatal("still entering a name")
+			}
+
+			scores, err := app.Scores.QueryScores(context.Background(), internal.ScoreQuery{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(scores) != 1 || scores[0].Name != test.want {
+				t.Fatalf("scores = %+v, want one named %q", scores, test.want)
+			}
+
+			if played := sessions.Sessions(); played[0].State != internal.SessionFinished {
+				t.Fatalf("session is %s once the name is in, want finished", played[0].State)
+			}
+		})
+	}
+}
+
+// failingScores is a score store that cannot be read.
+type failingScores struct {
+	*internal.InMemoryScoreService
+}
+
+func (failingScores) GetCurrentScore(context.Context) (internal.Score, error) {
+	return internal.Score{}, errors.New("disk I/O error")
+}
+
+func TestStoreFailureCarriesAcrossLevels(t *testing.T) {
+	app, sessions := guestApp("alice")
+	app.Guest = false
+	app.Scores = failingScores{internal.NewInMemoryScoreService("alice", sessions)}
+
+	first := game.InitalGameModel(game.LevelGameConfig(app, 0))
+	if app.StorageErr == nil || !app.Guest {
+		t.Fatalf("app after a failed read: StorageErr = %v, Guest = %t; want it switched to in-memory", app.StorageErr, app.Guest)
+	}
+
+	if err := first.Config.ScoreService.SetCurrentScore(context.Background(), 40, time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	next := game.InitalGameModel(game.LevelGameConfig(app, 1))
+	if next.Score != 40 {
+		t.Fatalf("next level starts on %d, want the 40 carried from the level before", next.Score)
+	}
+}
+
+func TestBell(t *testing.T) {
+	for _, sound := range []bool{true, false} {
+		t.Run(fmt.Sprintf("sound %t", sound), func(t *testing.T) {
+			app, _ := guestApp("alice")
+			app.Settings.Sound = sound
+			g := startGame(t, app, 0)
+
+			var cmd tea.Cmd
+			for !g.IsGameOver {
+				_, cmd = g.Update(game.Tick{})
+			}
+
+			// The bell goes out with the program's frames.
+			if rings := strings.Contains(g.View(), "\a"); rings != sound {
+				t.Fatalf("bell in the view when the snake dies: %t, want %t", rings, sound)
+			}
+
+			if !sound {
+				return
+			}
+
+			batch, ok := cmd().(tea.BatchMsg)
+			if !ok {
+				t.Fatal("dying rang no bell")
+			}
+
+			for _, cmd := range batch {
+				g.Update(cmd())
+			}
+
+			if strings.Contains(g.View(), "\a") {
+				t.Fatal("bell still in the view after it rang")
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+// Package gametest plays runs through the game engine for tests that need a
+// replay a leaderboard server will accept.
+package gametest
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/the-Jinxist/golang_snake_game/internal"
+	"github.com/the-Jinxist/golang_snake_game/tui/game"
+)
+
+// maxSeeds bounds how many seeds Play tries before giving up.
+const maxSeeds = 1000
+
+// Run is a run played through the engine, with the score it should be
+// submitted with.
+type Run struct {
+	Replay []internal.LevelReplay
+	Score  internal.Score
+}
+
+// Play steers a snake from the warm-up board up to level, clearing every
+// level before it, and stops once the score reaches value. With die set the
+// snake is then driven into a wall, so level must be a walled one. Seeds are
+// tried in turn until the steering gets there without crashing.
+func Play(level, value int, die bool) (Run, error) {
+	for seed := uint64(1); seed <= maxSeeds; seed++ {
+		run, ok := play(seed, level, value, die)
+		if ok {
+			return run, nil
+		}
+	}
+
+	return Run{}, fmt.Errorf("no run reaches %d on level %d within %d seeds", value, level, maxSeeds)
+}
+
+func play(seed uint64, level, value int, die bool) (Run, bool) {
+	var run Run
+
+	carried := 0
+	for current := 0; current <= level; current++ {
+		config := game.LevelGameConfig(nil, current)
+
+		goal := config.ScoreThreshold
+		if current == level {
+			goal = value
+		}
+
+		if goal < carried || goal > config.ScoreThreshold {
+			return run, false
+		}
+
+		engine := game.NewEngine(config.Rules(), seed+uint64(current), carried)
+		for engine.Score < goal {
+			if !steer(&engine, config, engine.Food.Position) || !engine.Step().Moved || engine.IsGameOver {
+				return run, false
+			}
+		}
+
+		if current == level && die {
+			if !config.IsWalled || !crash(&engine, config) {
+				return run, false
+			}
+
+			run.Score.DeathCause = engine.DeathCause
+			run.Score.DeathX = engine.DeathPosition.X
+			run.Score.DeathY = engine.DeathPosition.Y
+		}
+
+		run.Replay = append(run.Replay, engine.Replay(current))
+		carried = engine.Score
+	}
+
+	run.Score.Level = level
+	run.Score.Value = carried
+	return run, true
+}
+
+var moves = map[game.Direction]game.Position{
+	game.Up:    {Y: -1},
+	game.Down:  {Y: 1},
+	game.Left:  {X: -1},
+	game.Right: {X: 1},
+}
+
+// steer turns the snake towards target, taking the first move that does not
+// leave the board or hit a pillar or the snake.
+func steer(engine *game.Engine, config game.GameStartConfig, target game.Position) bool {
+	head := engine.Snake[0]
+
+	var preferred []game.Direction
+	switch {
+	case target.X > head.X:
+		preferred = append(preferred, game.Right)
+	case target.X < head.X:
+		preferred = append(preferred, game.Left)
+	}
+
+	switch {
+	case target.Y > head.Y:
+		preferred = append(preferred, game.Down)
+	case target.Y < head.Y:
+		preferred = append(preferred, game.Up)
+	}
+
+	preferred = append(preferred, engine.Direction, game.Up, game.Down, game.Left, game.Right)
+	for _, direction := range preferred {
+		if direction == opposite(engine.Direction) || !safe(engine, config, step(head, direction)) {
+			continue
+		}
+
+		if direction != engine.Direction {
+			engine.Turn(direction)
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// crash turns the snake to a wall it can reach without eating on the way
+// and runs into it.
+func crash(engine *game.Engine, config game.GameStartConfig) bool {
+	for _, direction := range []game.Direction{engine.Direction, game.Up, game.Down, game.Left, game.Right} {
+		if direction == opposite(engine.Direction) || !clearToWall(engine, config, direction) {
+			continue
+		}
+
+		if direction != engine.Direction {
+			engine.Turn(direction)
+		}
+
+		for !engine.IsGameOver {
+			if !engine.Step().Moved {
+				return false
+			}
+		}
+
+		return engine.DeathCause == internal.DeathWall
+	}
+
+	return false
+}
+
+func clearToWall(engine *game.Engine, config game.GameStartConfig, direction game.Direction) bool {
+	for cell := step(engine.Snake[0], direction); onBoard(config, cell); cell = step(cell, direction) {
+		if cell == engine.Food.Position || slices.Contains(engine.Snake, cell) || slices.Contains(config.Pillars, cell) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func safe(engine *game.Engine, config game.GameStartConfig, cell game.Position) bool {
+	return onBoard(config, cell) && !slices.Contains(engine.Snake, cell) && !slices.Contains(config.Pillars, cell)
+}
+
+func onBoard(config game.GameStartConfig, cell game.Position) bool {
+	return cell.X >= 0 && cell.Y >= 0 && cell.X < config.Rows && cell.Y < config.Columns
+}
+
+func step(from game.Position, direction game.Direction) game.Position {
+	move := moves[direction]
+	return game.Position{X: from.X + move.X, Y: from.Y + move.Y}
+}
+
+func opposite(direction game.Direction) game.Direction {
+	switch direction {
+	case game.Up:
+		return game.Down
+	case game.Down:
+		return game.Up
+	case game.Left:
+		return game.Right
+	default:
+		return game.Left
+	}
+}
@@ -0,0 +1,106 @@
+package game
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/the-Jinxist/golang_snake_game/tui/keys"
+	"github.com/the-Jinxist/golang_snake_game/tui/views"
+)
+
+const highScoreNameLimit = 12
+
+var _ views.InputCapturer = &GameModel{}
+
+// qualifiesForHighScore reports whether the finished run made it onto the
+// leaderboard shown by GetScores.
+func (g *GameModel) qualifiesForHighScore() bool {
+	if g.Score <= 0 {
+		return false
+	}
+
+	scores, err := g.Config.ScoreService.GetScores(context.Background())
+	if err != nil {
+		return false
+	}
+
+	session, _ := g.Config.SessionManager.GetCurrentSession()
+	for _, score := range scores {
+		if score.Session == session {
+			return true
+		}
+	}
+
+	return len(scores) < 5 || g.Score > scores[len(scores)-1].Value
+}
+
+func (g *GameModel) startNameEntry() tea.Cmd {
+	input := textinput.New()
+	input.Placeholder = "AAA"
+	input.CharLimit = highScoreNameLimit
+	input.Width = highScoreNameLimit
+	input.SetValue(g.Config.ScoreService.GetCurrentUser())
+
+	g.nameInput = input
+	g.isEnteringName = true
+	return g.nameInput.Focus()
+}
+
+// nameSavedMsg reports whether the entered name was saved.
+type nameSavedMsg struct {
+	err error
+}
+
+func (g *GameModel) updateNameEntry(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// The name can go to a leaderboard server, so it is saved as a command.
+	if key.Matches(msg, keys.Submit) {
+		scores, name := g.Config.ScoreService, g.nameInput.Value()
+		g.isSaving = true
+		return g, func() tea.Msg {
+			return nameSavedMsg{err: scores.SetCurrentScoreName(context.Background(), name)}
+		}
+	}
+
+	if key.Matches(msg, keys.Cancel) {
+		return g.nameSaved(nameSavedMsg{})
+	}
+
+	var cmd tea.Cmd
+	g.nameInput, cmd = g.nameInput.Update(msg)
+	return g, cmd
+}
+
+// nameSaved leaves the high-score screen once the name is in, or shows why
+// it could not be saved.
+func (g *GameModel) nameSaved(msg nameSavedMsg) (tea.Model, tea.Cmd) {
+	g.isSaving = false
+	if msg.err != nil {
+		g.nameErr = msg.err
+		return g, nil
+	}
+
+	g.isEnteringName = false
+	g.FinishSession()
+	return g, tea.Batch(views.ClearScreen(), views.SwitchModeCmd(views.ModeMenu))
+}
+
+func (g *GameModel) nameEntryView() string {
+	view := lipgloss.NewStyle().Bold(true).Render("NEW HIGH SCORE!")
+	view += fmt.Sprintf("\n%s\nYour final score is %d\n\nEnter your name: %s", g.deathMessage(), g.Score, g.nameInput.View())
+
+	if g.nameErr != nil {
+		view += "\n" + g.styles.error.Render(fmt.Sprintf("Could not save name: %s", g.nameErr))
+	}
+
+	view += fmt.Sprintf("\nPress %s to save or %s to skip", keys.Label(keys.Submit), keys.Label(keys.Cancel))
+	return view
+}
+
+// IsCapturingInput implements views.InputCapturer.
+func (g *GameModel) IsCapturingInput() bool {
+	return g.isEnteringName
+}
@@ -0,0 +1,119 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/the-Jinxist/golang_snake_game/internal"
+)
+
+// maxReplayTicks bounds how long a single level replay may run, so a forged
+// submission cannot keep the verifier busy.
+const maxReplayTicks = 1_000_000
+
+var ErrReplayMismatch = errors.New("replay does not reproduce the score")
+
+// ReplayVerifier re-simulates submitted runs with the level rules.
+type ReplayVerifier struct{}
+
+// Verify implements server.Verifier.
+func (ReplayVerifier) Verify(score internal.Score, replay []internal.LevelReplay) error {
+	return VerifyReplay(score, replay)
+}
+
+// VerifyReplay plays every level of replay back through the engine and
+// checks the run ends on the claimed score, level and death.
+func VerifyReplay(score internal.Score, replay []internal.LevelReplay) error {
+	if len(replay) == 0 {
+		return fmt.Errorf("%w: no levels recorded", ErrReplayMismatch)
+	}
+
+	// Every run starts on the warm-up board and clears one level at a time,
+	// so the replay must count up from it without gaps.
+	carried := 0
+	var last Engine
+	for index, level := range replay {
+		if level.Level < warmUpLevel || level.Level > finalLevel {
+			return fmt.Errorf("%w: there is no level %d", ErrReplayMismatch, level.Level)
+		}
+
+		if level.Level != warmUpLevel+index {
+			return fmt.Errorf("%w: level %d is out of order", ErrReplayMismatch, level.Level)
+		}
+
+		if level.StartScore != carried {
+			return fmt.Errorf("%w: level %d starts on %d, expected %d", ErrReplayMismatch, level.Level, level.StartScore, carried)
+		}
+
+		engine, err := simulateLevel(level)
+		if err != nil {
+			return err
+		}
+
+		// Every level but the last must have been cleared to move on.
+		if index < len(replay)-1 && !engine.hasReachedLevelThreshold() {
+			return fmt.Errorf("%w: level %d was never cleared", ErrReplayMismatch, level.Level)
+		}
+
+		carried = engine.Score
+		last = engine
+	}
+
+	if final := replay[len(replay)-1].Level; score.Level != final {
+		return fmt.Errorf("%w: score is for level %d, replay ends on level %d", ErrReplayMismatch, score.Level, final)
+	}
+
+	if last.Score != score.Value {
+		return fmt.Errorf("%w: replay scores %d, submitted %d", ErrReplayMismatch, last.Score, score.Value)
+	}
+
+	if score.DeathCause != internal.DeathNone && score.DeathCause != last.DeathCause {
+		return fmt.Errorf("%w: replay ends with %q, submitted %q", ErrReplayMismatch, last.DeathCause, score.DeathCause)
+	}
+
+	return nil
+}
+
+// replayRules returns the rules level was played by. Only the warm-up board's
+// walls are up to the player; every other level has a fixed layout.
+func replayRules(level internal.LevelReplay) Rules {
+	rules := LevelGameConfig(nil, level.Level).Rules()
+	if level.Level == warmUpLevel {
+		rules.IsWalled = !level.Open
+	}
+
+	return rules
+}
+
+func simulateLevel(level internal.LevelReplay) (Engine, error) {
+	if level.Ticks < 0 || level.Ticks > maxReplayTicks {
+		return Engine{}, fmt.Errorf("%w: level %d has %d moves", ErrReplayMismatch, level.Level, level.Ticks)
+	}
+
+	engine := NewEngine(replayRules(level), level.Seed, level.StartScore)
+
+	inputs := level.Inputs
+	for tick := range level.Ticks {
+		for len(inputs) > 0 && inputs[0].Tick == tick {
+			engine.Turn(Direction(inputs[0].Direction))
+			inputs = inputs[1:]
+		}
+
+		if len(inputs) > 0 && inputs[0].Tick < tick {
+			return engine, fmt.Errorf("%w: level %d has turns out of order", ErrReplayMismatch, level.Level)
+		}
+
+		if !engine.Step().Moved {
+			return engine, fmt.Errorf("%w: level %d keeps moving after it ended", ErrReplayMismatch, level.Level)
+		}
+	}
+
+	// Turns taken after the last move have not played out yet.
+	for _, input := range inputs {
+		if input.Tick != level.Ticks {
+			return engine, fmt.Errorf("%w: level %d has turns out of order", ErrReplayMismatch, level.Level)
+		}
+	}
+
+	return engine, nil
+}
@@ -0,0 +1,139 @@
+package game_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/the-Jinxist/golang_snake_game/internal"
+	"github.com/the-Jinxist/golang_snake_game/tui/game"
+	"github.com/the-Jinxist/golang_snake_game/tui/game/gametest"
+)
+
+func TestVerifyReplay(t *testing.T) {
+	warmUp := mustPlay(t, 0, 10, false)
+	levelOne := mustPlay(t, 1, 30, false)
+	crashed := mustPlay(t, 0, 10, true)
+
+	tests := []struct {
+		name   string
+		run    gametest.Run
+		change func(score *internal.Score, replay []internal.LevelReplay) []internal.LevelReplay
+		valid  bool
+	}{
+		{name: "warm-up run", run: warmUp, valid: true},
+		{name: "run into level 1", run: levelOne, valid: true},
+		{name: "run ending in a wall", run: crashed, valid: true},
+		{
+			name: "inflated score",
+			run:  levelOne,
+			change: func(score *internal.Score, replay []internal.LevelReplay) []internal.LevelReplay {
+				score.Value += 10
+				return replay
+			},
+		},
+		{
+			name: "claimed level differs from the replay",
+			run:  levelOne,
+			change: func(score *internal.Score, replay []internal.LevelReplay) []internal.LevelReplay {
+				score.Level = 2
+				return replay
+			},
+		},
+		{
+			name: "level past the last one",
+			run:  warmUp,
+			change: func(score *internal.Score, replay []internal.LevelReplay) []internal.LevelReplay {
+				score.Level = 7
+				replay[0].Level = 7
+				return replay
+			},
+		},
+		{
+			name: "negative level",
+			run:  warmUp,
+			change: func(score *internal.Score, replay []internal.LevelReplay) []internal.LevelReplay {
+				score.Level = -1
+				replay[0].Level = -1
+				return replay
+			},
+		},
+		{
+			name: "run without the warm-up board",
+			run:  levelOne,
+			change: func(score *internal.Score, replay []internal.LevelReplay) []internal.LevelReplay {
+				return replay[1:]
+			},
+		},
+		{
+			name: "skipped level",
+			run:  levelOne,
+			change: func(score *internal.Score, replay []internal.LevelReplay) []internal.LevelReplay {
+				score.Level = 2
+				replay[1].Level = 2
+				return replay
+			},
+		},
+		{
+			name: "no levels",
+			run:  warmUp,
+			change: func(score *internal.Score, replay []internal.LevelReplay) []internal.LevelReplay {
+				return nil
+			},
+		},
+		{
+			name: "wrong death",
+			run:  crashed,
+			change: func(score *internal.Score, replay []internal.LevelReplay) []internal.LevelReplay {
+				score.DeathCause = internal.DeathPillar
+				return replay
+			},
+		},
+		{
+			name: "turns out of order",
+			run:  levelOne,
+			change: func(score *internal.Score, replay []internal.LevelReplay) []internal.LevelReplay {
+				inputs := replay[0].Inputs
+				inputs[0], inputs[len(inputs)-1] = inputs[len(inputs)-1], inputs[0]
+				return replay
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			score, replay := test.run.Score, cloneReplay(test.run.Replay)
+			if test.change != nil {
+				replay = test.change(&score, replay)
+			}
+
+			err := game.VerifyReplay(score, replay)
+			switch {
+			case test.valid && err != nil:
+				t.Fatalf("VerifyReplay() = %v, want nil", err)
+			case !test.valid && !errors.Is(err, game.ErrReplayMismatch):
+				t.Fatalf("VerifyReplay() = %v, want ErrReplayMismatch", err)
+			}
+		})
+	}
+}
+
+func mustPlay(t *testing.T, level, value int, die bool) gametest.Run {
+	t.Helper()
+
+	run, err := gametest.Play(level, value, die)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return run
+}
+
+func cloneReplay(replay []internal.LevelReplay) []internal.LevelReplay {
+	cloned := make([]internal.LevelReplay, 0, len(replay))
+	for _, level := range replay {
+		level.Inputs = append([]internal.ReplayInput(nil), level.Inputs...)
+		cloned = append(cloned, level)
+	}
+
+	return cloned
+}
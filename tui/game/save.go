@@ -0,0 +1,262 @@
+package game
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/the-Jinxist/golang_snake_game/internal"
+)
+
+// savedGamesDirName holds one directory of saved games per store, with one
+// file per player in each.
+const savedGamesDirName = "saves"
+
+// SavedGame is the on-disk snapshot of an in-progress game.
+type SavedGame struct {
+	// Player and Store are who the game was played as and the absolute
+	// path of the store its session lives in; it can only be continued
+	// against the same pair.
+	Player string `json:"player"`
+	Store  string `json:"store"`
+
+	Level     int           `json:"level"`
+	Session   string        `json:"session"`
+	Snake     []Position    `json:"snake"`
+	Direction Direction     `json:"direction"`
+	Food      Food          `json:"food"`
+	Score     int           `json:"score"`
+	FoodEaten int           `json:"food_eaten"`
+	RNG       []byte        `json:"rng"`
+	Elapsed   time.Duration `json:"elapsed"`
+	SavedAt   time.Time     `json:"saved_at"`
+
+	// Progress is what the run has done towards achievements, so pausing
+	// to save still counts and big fish eaten before it are not forgotten.
+	Progress internal.RunProgress `json:"progress"`
+
+	// The level's replay so far, so a continued run can still be verified.
+	Seed       uint64                 `json:"seed"`
+	StartScore int                    `json:"start_score"`
+	Ticks      int                    `json:"ticks"`
+	Inputs     []internal.ReplayInput `json:"inputs"`
+}
+
+// savedGamesDir is the directory holding the saved games of store.
+func savedGamesDir(store string) (string, error) {
+	if store == "" {
+		return "", errors.New("games are not saved without a store")
+	}
+
+	dir, err := internal.DataDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, savedGamesDirName, pathKey(store)), nil
+}
+
+func savedGamePath(store, player string) (string, error) {
+	dir, err := savedGamesDir(store)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, pathKey(player)+".json"), nil
+}
+
+// pathKey turns a store path or player name into a safe file name.
+func pathKey(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:8])
+}
+
+// appPlayer is who app is currently playing as.
+func appPlayer(app *internal.App) string {
+	return app.Scores.GetCurrentUser()
+}
+
+// HasSavedGame reports whether app's current player has a game saved in
+// app's store.
+func HasSavedGame(app *internal.App) bool {
+	_, err := LoadSavedGame(app)
+	return err == nil
+}
+
+// LoadSavedGame reads the game app's current player saved in app's store.
+func LoadSavedGame(app *internal.App) (SavedGame, error) {
+	var saved SavedGame
+
+	player := appPlayer(app)
+	path, err := savedGamePath(app.StorePath, player)
+	if err != nil {
+		return saved, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return saved, err
+	}
+
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return saved, err
+	}
+
+	if saved.Player != player || saved.Store != app.StorePath {
+		return saved, fmt.Errorf("saved game belongs to %q in %s", saved.Player, saved.Store)
+	}
+
+	return saved, nil
+}
+
+// DeleteSavedGame removes the game app's current player saved in app's store.
+func DeleteSavedGame(app *internal.App) error {
+	path, err := savedGamePath(app.StorePath, appPlayer(app))
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+// DeleteSavedGames removes every player's saved game in store.
+func DeleteSavedGames(store string) error {
+	dir, err := savedGamesDir(store)
+	if err != nil {
+		return err
+	}
+
+	return os.RemoveAll(dir)
+}
+
+// CanSave reports whether the game is in a state worth resuming later.
+func (g *GameModel) CanSave() bool {
+	return !g.Config.Guest && !g.Config.IsDebugGrid && !g.IsGameOver && !g.hasReachedLevelThreshold()
+}
+
+// Save writes the current board to disk so it can be continued from the menu.
+func (g *GameModel) Save() error {
+	// A failed score write is already shown on screen; it should not stop
+	// the board from being saved.
+	g.FlushScore()
+
+	session, err := g.Config.SessionManager.GetCurrentSession()
+	if err != nil {
+		return err
+	}
+
+	rngState, err := g.source.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	var progress internal.RunProgress
+	if g.Config.Achievements != nil {
+		progress = g.Config.Achievements.Progress(session)
+	}
+
+	player := g.Config.ScoreService.GetCurrentUser()
+	data, err := json.Marshal(SavedGame{
+		Player: player,
+		Store:  g.Config.Store,
+
+		Level:     g.Config.Level,
+		Session:   session,
+		Snake:     g.Snake,
+		Direction: g.Direction,
+		Food:      g.Food,
+		Score:     g.Score,
+		FoodEaten: g.FoodEaten,
+		RNG:       rngState,
+		Elapsed:   g.Elapsed,
+		SavedAt:   time.Now(),
+		Progress:  progress,
+
+		Seed:       g.Seed,
+		StartScore: g.startScore,
+		Ticks:      g.Ticks,
+		Inputs:     g.Inputs,
+	})
+	if err != nil {
+		return err
+	}
+
+	path, err := savedGamePath(g.Config.Store, player)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	err = os.WriteFile(path, data, 0o644)
+	if err != nil {
+		return err
+	}
+
+	return g.Config.SessionManager.SuspendCurrentSession()
+}
+
+// RestoreGameModel rebuilds a game from a snapshot and resumes its session.
+func RestoreGameModel(gameConfig GameStartConfig, saved SavedGame) (*GameModel, error) {
+	source := &rand.PCG{}
+	if err := source.UnmarshalBinary(saved.RNG); err != nil {
+		return nil, err
+	}
+
+	if err := gameConfig.SessionManager.RestoreSession(saved.Session); err != nil {
+		return nil, err
+	}
+
+	if err := gameConfig.ScoreService.SetCurrentRun(context.Background(), gameConfig.RunInfo()); err != nil {
+		return nil, err
+	}
+
+	if gameConfig.Achievements != nil {
+		progress := saved.Progress
+		progress.Session = saved.Session
+		gameConfig.Achievements.ResumeProgress(progress)
+	}
+
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+
+	g := &GameModel{
+		Engine: Engine{
+			Snake:      saved.Snake,
+			Food:       saved.Food,
+			Direction:  saved.Direction,
+			Score:      saved.Score,
+			FoodEaten:  saved.FoodEaten,
+			Ticks:      saved.Ticks,
+			Seed:       saved.Seed,
+			Inputs:     saved.Inputs,
+			rules:      gameConfig.Rules(),
+			startScore: saved.StartScore,
+			source:     source,
+			rng:        rand.New(source),
+		},
+		Config:      gameConfig,
+		Elapsed:     saved.Elapsed,
+		spinner:     s,
+		scoreWriter: internal.NewScoreWriter(gameConfig.ScoreService),
+		styles:      newStyles(gameConfig.Theme),
+	}
+
+	g.emit(internal.EventLevelStarted, false)
+	return g, nil
+}
@@ -0,0 +1,126 @@
+package game_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/the-Jinxist/golang_snake_game/internal"
+	"github.com/the-Jinxist/golang_snake_game/tui/game"
+)
+
+// playerApp returns an app on in-memory services that saves games, as it
+// has a store, with the data directory in a temporary one.
+func playerApp(t *testing.T, player string) *internal.App {
+	t.Helper()
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+
+	sessions := internal.NewSessionManager()
+	sessions.SetUser(player)
+
+	return &internal.App{
+		StorePath: filepath.Join(t.TempDir(), "scores.db"),
+		Sessions:  sessions,
+		Scores:    internal.NewInMemoryScoreService(player, sessions),
+		Settings:  internal.DefaultSettings(),
+	}
+}
+
+// saveAndQuit picks "Save & quit" from the pause menu and returns the
+// message the save reports back with.
+func saveAndQuit(t *testing.T, g *game.GameModel) tea.Msg {
+	t.Helper()
+
+	keyMap := g.Config.Keys
+	g.Update(keyMsg(keyMap.Pause.Keys()[0]))
+	g.Update(keyMsg(keyMap.Down.Keys()[0]))
+
+	_, cmd := g.Update(keyMsg(keyMap.Select.Keys()[0]))
+	if cmd == nil {
+		t.Fatal("Save & quit returned no command")
+	}
+
+	return cmd()
+}
+
+func TestSaveAndContinue(t *testing.T) {
+	app := playerApp(t, "alice")
+	g := game.InitalGameModel(game.LevelGameConfig(app, 1))
+	for range 3 {
+		g.Update(game.Tick{})
+	}
+
+	_, cmd := g.Update(saveAndQuit(t, g))
+	if cmd == nil {
+		t.Fatal("a saved game did not quit")
+	}
+
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Fatal("a saved game did not quit")
+	}
+
+	saved, err := game.LoadSavedGame(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := game.RestoreGameModel(game.LevelGameConfig(app, saved.Level), saved)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if restored.Score != g.Score || restored.Direction != g.Direction || !slices.Equal(restored.Snake, g.Snake) || restored.Ticks != g.Ticks {
+		t.Fatalf("restored %+v, want the saved board %+v", restored.Engine, g.Engine)
+	}
+
+	app.Scores.SetCurrentUser("bob")
+	if game.HasSavedGame(app) {
+		t.Fatal("bob was offered alice's saved game")
+	}
+}
+
+func TestQuitAfterSaveFails(t *testing.T) {
+	app := playerApp(t, "alice")
+
+	// The data directory cannot be created under a file.
+	blocker := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(blocker, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("XDG_DATA_HOME", blocker)
+
+	g := game.InitalGameModel(game.LevelGameConfig(app, 1))
+	g.Update(game.Tick{})
+
+	if _, cmd := g.Update(saveAndQuit(t, g)); cmd != nil {
+		t.Fatal("quit although the game could not be saved")
+	}
+
+	if view := g.View(); !strings.Contains(view, "Could not save game") {
+		t.Fatalf("view does not report the failed save:\n%s", view)
+	}
+
+	cmd := g.Quit()
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Fatal("quitting again did not leave without saving")
+	}
+}
+
+func keyMsg(k string) tea.KeyMsg {
+	switch k {
+	case "up":
+		return tea.KeyMsg{Type: tea.KeyUp}
+	case "down":
+		return tea.KeyMsg{Type: tea.KeyDown}
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case " ":
+		return tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}
+	default:
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+	}
+}
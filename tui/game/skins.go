@@ -0,0 +1,88 @@
+package game
+
+import "github.com/the-Jinxist/golang_snake_game/tui/skin"
+
+// segmentIndexes maps each cell the snake covers to the index of the segment
+// on it. Where segments overlap, as they do for a move after eating, the one
+// nearest the head wins.
+func (e *Engine) segmentIndexes() map[Position]int {
+	indexes := make(map[Position]int, len(e.Snake))
+	for index := len(e.Snake) - 1; index >= 0; index-- {
+		indexes[e.Snake[index]] = index
+	}
+
+	return indexes
+}
+
+// segmentShape works out how the body segment at index bends between the
+// segment towards the head and the one towards the tail.
+func (e *Engine) segmentShape(index int) skin.Shape {
+	if index == len(e.Snake)-1 {
+		return skin.Tail
+	}
+
+	current := e.Snake[index]
+	toHead, headOK := side(current, e.Snake[index-1])
+	toTail, tailOK := side(current, e.Snake[index+1])
+
+	// A segment sharing its cell with a neighbour has no side towards it, so
+	// it runs straight along the one it does have.
+	if !headOK {
+		toHead = opposite(toTail)
+	}
+
+	if !tailOK {
+		toTail = opposite(toHead)
+	}
+
+	joins := func(a, b Direction) bool {
+		return (toHead == a && toTail == b) || (toHead == b && toTail == a)
+	}
+
+	switch {
+	case joins(Up, Right):
+		return skin.UpRight
+	case joins(Up, Left):
+		return skin.UpLeft
+	case joins(Down, Right):
+		return skin.DownRight
+	case joins(Down, Left):
+		return skin.DownLeft
+	case toHead == Up || toHead == Down:
+		return skin.Vertical
+	default:
+		return skin.Horizontal
+	}
+}
+
+// side returns which side of from the neighbouring cell to is on. On open
+// boards the neighbour may be across the edge, a whole board width away.
+func side(from, to Position) (Direction, bool) {
+	dx, dy := to.X-from.X, to.Y-from.Y
+
+	switch {
+	case dx == 1 || dx < -1:
+		return Right, true
+	case dx == -1 || dx > 1:
+		return Left, true
+	case dy == 1 || dy < -1:
+		return Down, true
+	case dy == -1 || dy > 1:
+		return Up, true
+	default:
+		return Right, false
+	}
+}
+
+func opposite(direction Direction) Direction {
+	switch direction {
+	case Up:
+		return Down
+	case Down:
+		return Up
+	case Left:
+		return Right
+	default:
+		return Left
+	}
+}
@@ -0,0 +1,39 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/the-Jinxist/golang_snake_game/tui/skin"
+)
+
+func TestSegmentShape(t *testing.T) {
+	tests := []struct {
+		name string
+		// snake runs from the head; the shape is the second segment's.
+		snake []Position
+		want  skin.Shape
+	}{
+		{name: "straight across", snake: []Position{{2, 0}, {1, 0}, {0, 0}}, want: skin.Horizontal},
+		{name: "straight down", snake: []Position{{0, 2}, {0, 1}, {0, 0}}, want: skin.Vertical},
+		{name: "bend down and right", snake: []Position{{1, 0}, {0, 0}, {0, 1}}, want: skin.DownRight},
+		{name: "bend down and left", snake: []Position{{1, 1}, {1, 0}, {0, 0}}, want: skin.DownLeft},
+		{name: "bend up and right", snake: []Position{{1, 1}, {0, 1}, {0, 0}}, want: skin.UpRight},
+		{name: "bend up and left", snake: []Position{{1, 0}, {1, 1}, {0, 1}}, want: skin.UpLeft},
+		{name: "tail", snake: []Position{{1, 0}, {0, 0}}, want: skin.Tail},
+		// On open boards a neighbour can be across the edge.
+		{name: "across the side edge", snake: []Position{{9, 0}, {0, 0}, {1, 0}}, want: skin.Horizontal},
+		{name: "bend across the top edge", snake: []Position{{0, 9}, {0, 0}, {1, 0}}, want: skin.UpRight},
+		// Right after eating the tail segment is doubled up.
+		{name: "sharing a cell with the tail", snake: []Position{{0, 1}, {0, 0}, {0, 0}}, want: skin.Vertical},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			engine := Engine{Snake: test.snake}
+
+			if got := engine.segmentShape(1); got != test.want {
+				t.Fatalf("segmentShape(1) of %v = %d, want %d", test.snake, got, test.want)
+			}
+		})
+	}
+}
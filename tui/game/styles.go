@@ -2,13 +2,13 @@ package game
 
 import (
 	"github.com/charmbracelet/lipgloss"
+	"github.com/the-Jinxist/golang_snake_game/tui/theme"
 	"github.com/the-Jinxist/golang_snake_game/utils"
 )
 
 const (
-	EmptyCell     = "░░"
-	FilledCell    = "██"
 	FoodCellApple = "🍎"
+	FoodCellFish  = "🐟"
 
 	SnakeHeadUp    = "◓◓"
 	SnakeHeadDown  = "◒◒"
@@ -16,22 +16,52 @@ const (
 	SnakeHeadRight = "◑◑"
 
 	PillarCell = "  "
-
-	FoodColor = "#DC3A35"
 )
 
-func FoodCell() string {
-	if utils.IsWindowsMachine() {
-		return lipgloss.NewStyle().Foreground(lipgloss.Color(FoodColor)).Render(FilledCell)
+// styles are the game's lipgloss styles in the player's theme.
+type styles struct {
+	board  lipgloss.Style
+	error  lipgloss.Style
+	help   lipgloss.Style
+	active lipgloss.Style
+	level  lipgloss.Style
+	toast  lipgloss.Style
+}
+
+func newStyles(t theme.Theme) styles {
+	return styles{
+		board:  lipgloss.NewStyle().Foreground(t.Board),
+		error:  lipgloss.NewStyle().Foreground(t.Error),
+		help:   lipgloss.NewStyle().Foreground(t.Muted),
+		active: lipgloss.NewStyle().Foreground(t.Accent),
+		level:  lipgloss.NewStyle().Align(lipgloss.Center).Padding(1).Foreground(t.OnAccent).Background(t.Accent),
+		toast:  lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(t.Gold).Padding(0, 2),
+	}
+}
+
+// FoodCell draws food as an emoji, or as a filled cell in the food colour on
+// Windows and in themes whose colours the emoji would ignore.
+func FoodCell(palette theme.Theme, bigFish bool) string {
+	if utils.IsWindowsMachine() || palette.PlainFood {
+		color := palette.Food
+		if bigFish {
+			color = palette.BigFish
+		}
+
+		return lipgloss.NewStyle().Foreground(color).Render(palette.FilledCell)
+	}
+
+	if bigFish {
+		return FoodCellFish
 	}
 
 	return FoodCellApple
 }
 
-func SnakeHeadFromDirection(direction Direction) string {
+func SnakeHeadFromDirection(palette theme.Theme, direction Direction) string {
 
 	if utils.IsWindowsMachine() {
-		return FilledCell
+		return palette.FilledCell
 	}
 
 	switch direction {
@@ -0,0 +1,287 @@
+// Package keys holds the key bindings every screen reads its input from, so
+// a player can swap the arrows and WASD for another preset or rebind single
+// actions in the settings file.
+package keys
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/the-Jinxist/golang_snake_game/internal"
+)
+
+// DefaultPreset is used when the settings name a preset that does not exist.
+const DefaultPreset = "default"
+
+// KeyMap is the full set of actions the screens respond to. Not every screen
+// uses every action; the leaderboard is the only one that pages and sorts.
+type KeyMap struct {
+	Up       key.Binding
+	Down     key.Binding
+	Left     key.Binding
+	Right    key.Binding
+	Select   key.Binding
+	Back     key.Binding
+	Pause    key.Binding
+	Quit     key.Binding
+	NextPage key.Binding
+	PrevPage key.Binding
+	Sort     key.Binding
+	Reverse  key.Binding
+}
+
+// Text fields always submit with enter and cancel with esc, whatever the
+// preset, so no binding can swallow a letter the player is typing.
+var (
+	Submit = key.NewBinding(key.WithKeys("enter"), key.WithHelp("", "confirm"))
+	Cancel = key.NewBinding(key.WithKeys("esc"), key.WithHelp("", "cancel"))
+)
+
+// preset describes a KeyMap as the keys bound to each action.
+type preset map[string][]string
+
+var presets = map[string]preset{
+	DefaultPreset: {
+		"up":        {"up", "w"},
+		"down":      {"down", "s"},
+		"left":      {"left", "a"},
+		"right":     {"right", "d"},
+		"select":    {"enter", " "},
+		"back":      {"esc"},
+		"pause":     {" "},
+		"quit":      {"q", "ctrl+c"},
+		"next_page": {"n", "pgdown"},
+		"prev_page": {"p", "pgup"},
+		"sort":      {"o"},
+		"reverse":   {"r"},
+	},
+	"vim": {
+		"up":        {"up", "k"},
+		"down":      {"down", "j"},
+		"left":      {"left", "h"},
+		"right":     {"right", "l"},
+		"select":    {"enter", " "},
+		"back":      {"esc"},
+		"pause":     {" ", "p"},
+		"quit":      {"q", "ctrl+c"},
+		"next_page": {"ctrl+f", "pgdown"},
+		"prev_page": {"ctrl+b", "pgup"},
+		"sort":      {"o"},
+		"reverse":   {"r"},
+	},
+	// one-handed keeps everything under the left hand, so q goes back a
+	// screen and quitting is left to ctrl+c.
+	"one-handed": {
+		"up":        {"up", "w"},
+		"down":      {"down", "s"},
+		"left":      {"left", "a"},
+		"right":     {"right", "d"},
+		"select":    {" ", "e", "enter"},
+		"back":      {"q", "esc"},
+		"pause":     {" "},
+		"quit":      {"ctrl+c"},
+		"next_page": {"f", "pgdown"},
+		"prev_page": {"r", "pgup"},
+		"sort":      {"z"},
+		"reverse":   {"x"},
+	},
+}
+
+// Presets lists the preset names in the order the settings screen cycles
+// them.
+var Presets = []string{DefaultPreset, "vim", "one-handed"}
+
+var descriptions = map[string]string{
+	"up":        "move up",
+	"down":      "move down",
+	"left":      "move left",
+	"right":     "move right",
+	"select":    "select",
+	"back":      "go back",
+	"pause":     "pause",
+	"quit":      "quit",
+	"next_page": "next page",
+	"prev_page": "previous page",
+	"sort":      "sort column",
+	"reverse":   "reverse order",
+}
+
+// screens lists the actions each screen responds to, to find overrides
+// that give one key two jobs. Quit is caught on every screen.
+var screens = []struct {
+	name    string
+	actions []string
+}{
+	{"menu", []string{"up", "down", "select", "quit"}},
+	{"game", []string{"up", "down", "left", "right", "select", "back", "pause", "quit"}},
+	{"leaderboard", []string{"up", "down", "left", "right", "back", "next_page", "prev_page", "sort", "reverse", "quit"}},
+	{"settings", []string{"up", "down", "left", "right", "select", "back", "quit"}},
+	{"profiles", []string{"up", "down", "select", "back", "quit"}},
+}
+
+// FromSettings builds the KeyMap for the player's chosen preset with their
+// key_overrides applied on top. An unknown preset falls back to the default
+// one. Overrides for actions that do not exist are ignored, and so are
+// overrides CheckOverrides rejects.
+func FromSettings(settings internal.Settings) KeyMap {
+	bound := presetFor(settings)
+	rejected, _ := overrideConflicts(bound, settings.KeyOverrides)
+
+	binding := func(action string) key.Binding {
+		keys := bound[action]
+		if override, ok := settings.KeyOverrides[action]; ok && len(override) > 0 && !rejected[action] {
+			keys = override
+		}
+
+		return key.NewBinding(key.WithKeys(keys...), key.WithHelp("", descriptions[action]))
+	}
+
+	return KeyMap{
+		Up:       binding("up"),
+		Down:     binding("down"),
+		Left:     binding("left"),
+		Right:    binding("right"),
+		Select:   binding("select"),
+		Back:     binding("back"),
+		Pause:    binding("pause"),
+		Quit:     binding("quit"),
+		NextPage: binding("next_page"),
+		PrevPage: binding("prev_page"),
+		Sort:     binding("sort"),
+		Reverse:  binding("reverse"),
+	}
+}
+
+// CheckOverrides reports key_overrides that bind a key to two actions on
+// the same screen. Keys the preset itself shares, such as space to select
+// and to pause, are allowed.
+func CheckOverrides(settings internal.Settings) error {
+	_, err := overrideConflicts(presetFor(settings), settings.KeyOverrides)
+	return err
+}
+
+func presetFor(settings internal.Settings) preset {
+	if bound, ok := presets[settings.Keys]; ok {
+		return bound
+	}
+
+	return presets[DefaultPreset]
+}
+
+// overrideConflicts returns the overridden actions that share a key with
+// another action on one of its screens, and an error describing each clash.
+func overrideConflicts(bound preset, overrides map[string][]string) (map[string]bool, error) {
+	keysFor := func(action string) []string {
+		if override, ok := overrides[action]; ok && len(override) > 0 {
+			return override
+		}
+
+		return bound[action]
+	}
+
+	rejected := make(map[string]bool)
+	reported := make(map[[2]string]bool)
+
+	var errs []error
+	for _, screen := range screens {
+		for i, first := range screen.actions {
+			for _, second := range screen.actions[i+1:] {
+				firstOverridden, secondOverridden := len(overrides[first]) > 0, len(overrides[second]) > 0
+				if !firstOverridden && !secondOverridden || reported[[2]string{first, second}] {
+					continue
+				}
+
+				for _, k := range keysFor(first) {
+					if !slices.Contains(keysFor(second), k) || slices.Contains(bound[first], k) && slices.Contains(bound[second], k) {
+						continue
+					}
+
+					rejected[first] = rejected[first] || firstOverridden
+					rejected[second] = rejected[second] || secondOverridden
+					reported[[2]string{first, second}] = true
+					errs = append(errs, fmt.Errorf("key_overrides: %s would both %s and %s on the %s screen", keyName(k), descriptions[first], descriptions[second], screen.name))
+					break
+				}
+			}
+		}
+	}
+
+	return rejected, errors.Join(errs...)
+}
+
+// Describe merges bindings into one described by desc, for screens where
+// several actions do the same thing, e.g. → and ENTER both change a setting.
+func Describe(desc string, bindings ...key.Binding) key.Binding {
+	var keys []string
+	for _, binding := range bindings {
+		for _, k := range binding.Keys() {
+			if !slices.Contains(keys, k) {
+				keys = append(keys, k)
+			}
+		}
+	}
+
+	return key.NewBinding(key.WithKeys(keys...), key.WithHelp("", desc))
+}
+
+// Label names a binding's keys the way help text shows them, e.g. "↑ or W"
+// or "←, → or ENTER".
+func Label(binding key.Binding) string {
+	names := keyNames(binding)
+	if len(names) < 2 {
+		return strings.Join(names, "")
+	}
+
+	return strings.Join(names[:len(names)-1], ", ") + " or " + names[len(names)-1]
+}
+
+func keyNames(binding key.Binding) []string {
+	names := make([]string, 0, len(binding.Keys()))
+	for _, k := range binding.Keys() {
+		names = append(names, keyName(k))
+	}
+
+	return names
+}
+
+func keyName(k string) string {
+	switch k {
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case "left":
+		return "←"
+	case "right":
+		return "→"
+	case " ":
+		return "SPACE"
+	default:
+		return strings.ToUpper(k)
+	}
+}
+
+// Instructions renders the [INSTRUCTIONS] block shown under a screen, one
+// line per binding.
+func Instructions(bindings ...key.Binding) string {
+	help := "\n[INSTRUCTIONS]:"
+	for _, binding := range bindings {
+		help += "\n· " + Label(binding) + " to " + binding.Help().Desc
+	}
+
+	return help
+}
+
+// Inline renders bindings on a single line, for screens with little room
+// left under their content.
+func Inline(bindings ...key.Binding) string {
+	parts := make([]string, 0, len(bindings))
+	for _, binding := range bindings {
+		parts = append(parts, "["+strings.Join(keyNames(binding), "/")+"] "+binding.Help().Desc)
+	}
+
+	return strings.Join(parts, " · ")
+}
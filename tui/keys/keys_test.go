@@ -0,0 +1,58 @@
+package keys_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/the-Jinxist/golang_snake_game/internal"
+	"github.com/the-Jinxist/golang_snake_game/tui/keys"
+)
+
+func TestOverrides(t *testing.T) {
+	tests := []struct {
+		name      string
+		preset    string
+		overrides map[string][]string
+		// wantSort is the keys sorting ends up on.
+		wantSort []string
+		wantErr  bool
+	}{
+		{name: "no overrides", preset: "default", wantSort: []string{"o"}},
+		{name: "vim pause on p alone", preset: "vim", overrides: map[string][]string{"pause": {"p"}}, wantSort: []string{"o"}},
+		{name: "keys the preset already shares", preset: "default", overrides: map[string][]string{"select": {"enter", " "}}, wantSort: []string{"o"}},
+		{name: "free key", preset: "default", overrides: map[string][]string{"sort": {"t"}}, wantSort: []string{"t"}},
+		{name: "sort on a movement key", preset: "default", overrides: map[string][]string{"sort": {"s"}}, wantSort: []string{"o"}, wantErr: true},
+		{name: "quit on a movement key", preset: "vim", overrides: map[string][]string{"quit": {"j"}}, wantSort: []string{"o"}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			settings := internal.DefaultSettings()
+			settings.Keys = test.preset
+			settings.KeyOverrides = test.overrides
+
+			if err := keys.CheckOverrides(settings); (err != nil) != test.wantErr {
+				t.Fatalf("CheckOverrides() = %v, want error: %t", err, test.wantErr)
+			}
+
+			if got := keys.FromSettings(settings).Sort.Keys(); !slices.Equal(got, test.wantSort) {
+				t.Fatalf("sort is bound to %v, want %v", got, test.wantSort)
+			}
+		})
+	}
+}
+
+func TestSortIsNotAMovementKey(t *testing.T) {
+	for _, preset := range keys.Presets {
+		settings := internal.DefaultSettings()
+		settings.Keys = preset
+
+		keyMap := keys.FromSettings(settings)
+		movement := keys.Describe("move", keyMap.Up, keyMap.Down, keyMap.Left, keyMap.Right)
+		for _, k := range keyMap.Sort.Keys() {
+			if slices.Contains(movement.Keys(), k) {
+				t.Fatalf("%s preset binds %q to both sorting and moving", preset, k)
+			}
+		}
+	}
+}
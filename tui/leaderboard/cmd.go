@@ -1,15 +1,23 @@
 package leaderboard
 
-import "github.com/the-Jinxist/golang_snake_game/internal"
+import (
+	"github.com/the-Jinxist/golang_snake_game/internal"
+	"github.com/the-Jinxist/golang_snake_game/tui/keys"
+	"github.com/the-Jinxist/golang_snake_game/tui/theme"
+)
 
 type LeaderboardConfig struct {
 	ScoreService   internal.ScoreService
 	SessionManager internal.SessionManager
+	Keys           keys.KeyMap
+	Theme          theme.Theme
 }
 
-func DefaultLeaderboardConfig() LeaderboardConfig {
+func DefaultLeaderboardConfig(app *internal.App) LeaderboardConfig {
 	return LeaderboardConfig{
-		ScoreService:   internal.GetScoreService(),
-		SessionManager: internal.GetSessionManager(),
+		ScoreService:   app.Scores,
+		SessionManager: app.Sessions,
+		Keys:           keys.FromSettings(app.Settings),
+		Theme:          theme.FromApp(app),
 	}
 }
@@ -3,10 +3,17 @@ package leaderboard
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/the-Jinxist/golang_snake_game/internal"
+	"github.com/the-Jinxist/golang_snake_game/tui/keys"
+	"github.com/the-Jinxist/golang_snake_game/tui/theme"
 	"github.com/the-Jinxist/golang_snake_game/tui/views"
 )
 
@@ -19,73 +26,368 @@ const leaderboardTitle = `
         \/   \/     \/      \/    \/          \/           \/           \/ 
 `
 
-var (
-	_ tea.Model = &Leaderboard{}
-
-	highscoreStyle = lipgloss.NewStyle().MarginLeft(2).PaddingLeft(1).PaddingRight(1).Background(lipgloss.Color("#87CEFA"))
-	scoreStyle     = lipgloss.NewStyle().Bold(true)
-	descStyle      = lipgloss.NewStyle().Italic(true).Foreground(lipgloss.Color("#DDDDDD"))
+const (
+	pageSize         = 10
+	currentPlayerTag = "★ "
 )
 
+var _ tea.Model = &Leaderboard{}
+
+type leaderboardTab struct {
+	Title string
+	Query internal.ScoreQuery
+}
+
+func defaultTabs() []leaderboardTab {
+	tabs := []leaderboardTab{{Title: "All"}}
+	for level := 1; level <= 5; level++ {
+		tabs = append(tabs, leaderboardTab{
+			Title: fmt.Sprintf("Level %d", level),
+			Query: internal.ScoreQuery{Level: &level},
+		})
+	}
+
+	return append(tabs,
+		leaderboardTab{Title: "Time Attack", Query: internal.ScoreQuery{Mode: internal.GameModeTimeAttack}},
+		leaderboardTab{Title: "Daily", Query: internal.ScoreQuery{Mode: internal.GameModeDaily}},
+	)
+}
+
+type leaderboardColumn struct {
+	Title  string
+	Width  int
+	SortBy internal.ScoreSort
+}
+
+var leaderboardColumns = []leaderboardColumn{
+	{Title: "Rank", Width: 6, SortBy: internal.SortByScore},
+	{Title: "Player", Width: 16, SortBy: internal.SortByPlayer},
+	{Title: "Score", Width: 8, SortBy: internal.SortByScore},
+	{Title: "Level", Width: 6, SortBy: internal.SortByLevel},
+	{Title: "Duration", Width: 9, SortBy: internal.SortByDuration},
+	{Title: "Date", Width: 18, SortBy: internal.SortByDate},
+	{Title: "Verified", Width: 10, SortBy: internal.SortByVerified},
+}
+
+// styles are the screen's lipgloss styles in the player's theme.
+type styles struct {
+	desc      lipgloss.Style
+	tab       lipgloss.Style
+	activeTab lipgloss.Style
+	table     lipgloss.Style
+	error     lipgloss.Style
+	help      lipgloss.Style
+}
+
+func newStyles(t theme.Theme) styles {
+	return styles{
+		desc:      lipgloss.NewStyle().Italic(true).Foreground(t.Text),
+		tab:       lipgloss.NewStyle().Padding(0, 1).Foreground(t.Subtle),
+		activeTab: lipgloss.NewStyle().Padding(0, 1).Bold(true).Foreground(t.OnAccent).Background(t.Accent),
+		table:     lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderForeground(t.Muted),
+		error:     lipgloss.NewStyle().Foreground(t.Error),
+		help:      lipgloss.NewStyle().Foreground(t.Muted),
+	}
+}
+
 type Leaderboard struct {
-	Scores []internal.Score
-	Config LeaderboardConfig
+	Scores     []internal.Score
+	Config     LeaderboardConfig
+	tabs       []leaderboardTab
+	activeTab  int
+	page       int
+	totalCount int
+	sortColumn int
+	ascending  bool
+	table      table.Model
+	err        error
+	styles     styles
+
+	// pending is how many of the player's scores are still waiting to reach
+	// the leaderboard server.
+	pending int
+
+	// loads counts the loads started, so a page the player has already
+	// moved past is dropped when it arrives.
+	loads  int
+	loaded bool
 }
 
-func NewLeaderboardModel(config LeaderboardConfig) *Leaderboard {
+// scoresLoadedMsg carries a page of scores read by loadScores.
+type scoresLoadedMsg struct {
+	load    int
+	scores  []internal.Score
+	count   int
+	pending int
+	err     error
+}
 
-	const defaultWidth = 20
-	scores, _ := config.ScoreService.GetScores(context.Background())
+// submissionQueue is implemented by score stores that queue scores for a
+// leaderboard server while it cannot be reached.
+type submissionQueue interface {
+	PendingSubmissions() int
+}
 
-	return &Leaderboard{
+func NewLeaderboardModel(config LeaderboardConfig) *Leaderboard {
+	tableStyles := table.DefaultStyles()
+	tableStyles.Header = tableStyles.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(config.Theme.Muted).
+		BorderBottom(true).
+		Bold(true)
+	tableStyles.Selected = tableStyles.Selected.
+		Foreground(config.Theme.OnAccent).
+		Background(config.Theme.Accent).
+		Bold(false)
+
+	l := &Leaderboard{
 		Config: config,
-		Scores: scores,
+		tabs:   defaultTabs(),
+		styles: newStyles(config.Theme),
+		table: table.New(
+			table.WithFocused(true),
+			table.WithHeight(pageSize+1),
+			table.WithStyles(tableStyles),
+			// Only moving a row at a time is bound, so the table's own
+			// paging keys cannot clash with the leaderboard's.
+			table.WithKeyMap(table.KeyMap{LineUp: config.Keys.Up, LineDown: config.Keys.Down}),
+		),
+	}
+
+	return l
+}
+
+func (l *Leaderboard) query() internal.ScoreQuery {
+	query := l.tabs[l.activeTab].Query
+	query.Limit = pageSize
+	query.Offset = l.page * pageSize
+	query.SortBy = leaderboardColumns[l.sortColumn].SortBy
+	query.Ascending = l.ascending
+
+	return query
+}
+
+func (l *Leaderboard) pageCount() int {
+	if l.totalCount == 0 {
+		return 1
+	}
+
+	return (l.totalCount + pageSize - 1) / pageSize
+}
+
+// loadScores reads the current page. The board can come from a leaderboard
+// server, so it is read as a command and shown once it arrives.
+func (l *Leaderboard) loadScores() tea.Cmd {
+	l.loads++
+	load, query, scores := l.loads, l.query(), l.Config.ScoreService
+
+	return func() tea.Msg {
+		count, countErr := scores.CountScores(context.Background(), query)
+		page, err := scores.QueryScores(context.Background(), query)
+		if err == nil {
+			err = countErr
+		}
+
+		pending := 0
+		if queue, ok := scores.(submissionQueue); ok {
+			pending = queue.PendingSubmissions()
+		}
+
+		return scoresLoadedMsg{load: load, scores: page, count: count, pending: pending, err: err}
 	}
 }
 
+func (l *Leaderboard) scoresLoaded(msg scoresLoadedMsg) {
+	if msg.load != l.loads {
+		return
+	}
+
+	l.loaded = true
+	l.Scores, l.totalCount, l.pending, l.err = msg.scores, msg.count, msg.pending, msg.err
+
+	l.table.SetColumns(l.columns())
+	l.table.SetRows(l.rows())
+	l.table.SetCursor(l.currentPlayerRow())
+}
+
+func (l *Leaderboard) columns() []table.Column {
+	columns := make([]table.Column, 0, len(leaderboardColumns))
+	for index, column := range leaderboardColumns {
+		title := column.Title
+		if index == l.sortColumn {
+			if l.ascending {
+				title += " ▲"
+			} else {
+				title += " ▼"
+			}
+		}
+
+		columns = append(columns, table.Column{Title: title, Width: column.Width})
+	}
+
+	return columns
+}
+
+func (l *Leaderboard) rows() []table.Row {
+	currentUser := l.Config.ScoreService.GetCurrentUser()
+
+	rows := make([]table.Row, 0, len(l.Scores))
+	for _, score := range l.Scores {
+		player := score.DisplayName()
+		if score.User == currentUser {
+			player = currentPlayerTag + player
+		}
+
+		rows = append(rows, table.Row{
+			strconv.Itoa(score.Rank),
+			player,
+			strconv.Itoa(score.Value),
+			strconv.Itoa(score.Level),
+			formatDuration(score.Duration),
+			score.CreatedAt.Local().Format("Jan 2 2006 15:04"),
+			verificationLabel(score.Verification),
+		})
+	}
+
+	return rows
+}
+
+// currentPlayerRow puts the cursor on the current player's first entry on the
+// page, so their score stands out when the board is opened.
+func (l *Leaderboard) currentPlayerRow() int {
+	currentUser := l.Config.ScoreService.GetCurrentUser()
+	for index, score := range l.Scores {
+		if score.User == currentUser {
+			return index
+		}
+	}
+
+	return 0
+}
+
+// verificationLabel marks scores a leaderboard server re-simulated from their
+// replay.
+func verificationLabel(verification internal.Verification) string {
+	if verification == internal.Verified {
+		return "✓ replay"
+	}
+
+	return "-"
+}
+
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	return fmt.Sprintf("%d:%02d", int(d.Minutes()), int(d.Seconds())%60)
+}
+
 // Init implements tea.Model.
 func (l *Leaderboard) Init() tea.Cmd {
-	return nil
+	return l.loadScores()
 }
 
 // Update implements tea.Model.
 func (l *Leaderboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case scoresLoadedMsg:
+		l.scoresLoaded(msg)
+		return l, nil
 
 	case tea.KeyMsg:
-		switch keypress := msg.String(); keypress {
-		case "ctrl+c":
+		keyMap := l.Config.Keys
+
+		switch {
+		case key.Matches(msg, keyMap.Quit):
 			return l, tea.Quit
-		case "esc":
+		case key.Matches(msg, keyMap.Back):
 			return l, tea.Batch(views.ClearScreen(), views.SwitchModeCmd(views.ModeMenu))
+		case key.Matches(msg, keyMap.Left):
+			l.activeTab = (l.activeTab - 1 + len(l.tabs)) % len(l.tabs)
+			l.page = 0
+			return l, l.loadScores()
+		case key.Matches(msg, keyMap.Right):
+			l.activeTab = (l.activeTab + 1) % len(l.tabs)
+			l.page = 0
+			return l, l.loadScores()
+		case key.Matches(msg, keyMap.NextPage):
+			if l.page < l.pageCount()-1 {
+				l.page++
+				return l, l.loadScores()
+			}
+			return l, nil
+		case key.Matches(msg, keyMap.PrevPage):
+			if l.page > 0 {
+				l.page--
+				return l, l.loadScores()
+			}
+			return l, nil
+		case key.Matches(msg, keyMap.Sort):
+			l.sortColumn = (l.sortColumn + 1) % len(leaderboardColumns)
+			l.page = 0
+			return l, l.loadScores()
+		case key.Matches(msg, keyMap.Reverse):
+			l.ascending = !l.ascending
+			l.page = 0
+			return l, l.loadScores()
 		}
 	}
 
 	var cmd tea.Cmd
+	l.table, cmd = l.table.Update(msg)
 	return l, cmd
 }
 
 // View implements tea.Model.
 func (l *Leaderboard) View() string {
 
-	title := leaderboardTitle
-	description := "\n\n"
+	title := l.Config.Theme.Banner(leaderboardTitle)
+	description := "\n" + l.tabsView() + "\n\n"
 
-	for i, value := range l.Scores {
-		description += scoreStyle.Render(fmt.Sprintf("Score: %d", value.Value))
-		if i == 0 {
-			description += highscoreStyle.Render("Highscore")
-		}
+	if !l.loaded {
+		description += l.styles.desc.Render("Loading scores…") + "\n\n"
+	} else if l.err != nil {
+		description += l.styles.error.Render(fmt.Sprintf("Could not load scores: %s", l.err)) + "\n\n"
+	} else if len(l.Scores) == 0 {
+		description += l.styles.desc.Render("No scores recorded yet") + "\n\n"
+	} else {
+		description += l.styles.table.Render(l.table.View()) + "\n"
+		description += l.styles.desc.Render(fmt.Sprintf(
+			"Page %d of %d · %d scores · %s marks your scores",
+			l.page+1, l.pageCount(), l.totalCount, strings.TrimSpace(currentPlayerTag),
+		))
+	}
 
-		description += "\n"
-		description += descStyle.Render(fmt.Sprintf("Recorded at %s", value.CreatedAt.GoString()))
+	if l.pending > 0 {
+		waiting := fmt.Sprintf("%d scores are", l.pending)
+		if l.pending == 1 {
+			waiting = "1 score is"
+		}
 
-		description += "\n\n"
+		description += "\n" + l.styles.desc.Render(waiting+" waiting to reach the leaderboard server and will be sent with the next one")
 	}
 
-	help := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#444745")).
-		Render("\n\n\nPress [esc] to return back to menu screen")
+	help := l.styles.help.Render("\n\n" + keys.Inline(
+		keys.Describe("switch boards", l.Config.Keys.Left, l.Config.Keys.Right),
+		keys.Describe("move", l.Config.Keys.Up, l.Config.Keys.Down),
+		l.Config.Keys.NextPage,
+		l.Config.Keys.PrevPage,
+		l.Config.Keys.Sort,
+		l.Config.Keys.Reverse,
+		keys.Describe("back to menu", l.Config.Keys.Back),
+	))
 
 	return title + description + help
 }
+
+func (l *Leaderboard) tabsView() string {
+	tabs := make([]string, 0, len(l.tabs))
+	for index, tab := range l.tabs {
+		style := l.styles.tab
+		if index == l.activeTab {
+			style = l.styles.activeTab
+		}
+
+		tabs = append(tabs, style.Render(tab.Title))
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, tabs...)
+}
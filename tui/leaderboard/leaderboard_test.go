@@ -0,0 +1,269 @@
+package leaderboard
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/the-Jinxist/golang_snake_game/internal"
+)
+
+// openBoard opens a leaderboard on an in-memory store holding recorded and
+// shows its first load.
+func openBoard(t *testing.T, recorded []internal.Score) *Leaderboard {
+	t.Helper()
+
+	sessions := internal.NewSessionManager()
+	scores := internal.NewInMemoryScoreService("alice", sessions)
+	if _, err := scores.ImportScores(context.Background(), recorded); err != nil {
+		t.Fatal(err)
+	}
+
+	app := &internal.App{Guest: true, Sessions: sessions, Scores: scores, Settings: internal.DefaultSettings()}
+	board := NewLeaderboardModel(DefaultLeaderboardConfig(app))
+	board.Update(board.Init()())
+
+	return board
+}
+
+// press sends keys to board, showing each load they start.
+func press(board *Leaderboard, keys ...tea.KeyMsg) {
+	for _, msg := range keys {
+		if _, cmd := board.Update(msg); cmd != nil {
+			board.Update(cmd())
+		}
+	}
+}
+
+func TestLeaderboardTabs(t *testing.T) {
+	recorded := []internal.Score{
+		{User: "alice", Session: "a", Value: 10, Level: 1, Mode: internal.GameModeClassic},
+		{User: "alice", Session: "b", Value: 20, Level: 2, Mode: internal.GameModeClassic},
+		{User: "alice", Session: "c", Value: 30, Level: 1, Mode: internal.GameModeTimeAttack},
+		{User: "alice", Session: "d", Value: 40, Level: 1, Mode: internal.GameModeDaily},
+	}
+
+	right, left := tea.KeyMsg{Type: tea.KeyRight}, tea.KeyMsg{Type: tea.KeyLeft}
+
+	tests := []struct {
+		name string
+		keys []tea.KeyMsg
+		tab  string
+		want []int
+	}{
+		{name: "all", tab: "All", want: []int{40, 30, 20, 10}},
+		{name: "level 1", keys: []tea.KeyMsg{right}, tab: "Level 1", want: []int{40, 30, 10}},
+		{name: "level 2", keys: []tea.KeyMsg{right, right}, tab: "Level 2", want: []int{20}},
+		{name: "empty level", keys: []tea.KeyMsg{right, right, right}, tab: "Level 3", want: []int{}},
+		{name: "wraps to daily", keys: []tea.KeyMsg{left}, tab: "Daily", want: []int{40}},
+		{name: "time attack", keys: []tea.KeyMsg{left, left}, tab: "Time Attack", want: []int{30}},
+		{name: "back round to all", keys: []tea.KeyMsg{left, right}, tab: "All", want: []int{40, 30, 20, 10}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			board := openBoard(t, recorded)
+			press(board, test.keys...)
+
+			if tab := board.tabs[board.activeTab].Title; tab != test.tab {
+				t.Fatalf("tab = %q, want %q", tab, test.tab)
+			}
+
+			if got := values(board.Scores); !slices.Equal(got, test.want) {
+				t.Fatalf("scores = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestLeaderboardPages(t *testing.T) {
+	var recorded []internal.Score
+	for index := range 25 {
+		recorded = append(recorded, internal.Score{
+			User:    "alice",
+			Session: string(rune('a' + index)),
+			Value:   (index + 1) * 10,
+		})
+	}
+
+	tests := []struct {
+		name  string
+		keys  []tea.KeyMsg
+		page  int
+		first int
+		count int
+	}{
+		{name: "first page", first: 250, count: pageSize},
+		{name: "next page", keys: []tea.KeyMsg{runes("n")}, page: 1, first: 150, count: pageSize},
+		{name: "last page", keys: []tea.KeyMsg{runes("n"), runes("n")}, page: 2, first: 50, count: 5},
+		{name: "past the last page", keys: []tea.KeyMsg{runes("n"), runes("n"), runes("n")}, page: 2, first: 50, count: 5},
+		{name: "back a page", keys: []tea.KeyMsg{runes("n"), runes("p")}, first: 250, count: pageSize},
+		{name: "reversed", keys: []tea.KeyMsg{runes("r")}, first: 10, count: pageSize},
+		{name: "reversing goes back to the first page", keys: []tea.KeyMsg{runes("n"), runes("r")}, first: 10, count: pageSize},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			board := openBoard(t, recorded)
+			press(board, test.keys...)
+
+			if board.page != test.page {
+				t.Fatalf("page = %d, want %d", board.page, test.page)
+			}
+
+			if board.totalCount != len(recorded) {
+				t.Fatalf("total = %d, want %d", board.totalCount, len(recorded))
+			}
+
+			if len(board.Scores) != test.count || board.Scores[0].Value != test.first {
+				t.Fatalf("page values = %v, want %d scores from %d", values(board.Scores), test.count, test.first)
+			}
+		})
+	}
+}
+
+func TestLeaderboardSort(t *testing.T) {
+	recorded := []internal.Score{
+		{User: "bob", Session: "a", Value: 30, Level: 1, Duration: 3 * time.Second},
+		{User: "alice", Session: "b", Value: 10, Level: 3, Duration: 1 * time.Second},
+		{User: "carol", Session: "c", Value: 20, Level: 2, Duration: 2 * time.Second},
+	}
+
+	tests := []struct {
+		name   string
+		keys   []tea.KeyMsg
+		column string
+		want   []int
+	}{
+		{name: "by rank", column: "Rank ▼", want: []int{30, 20, 10}},
+		{name: "by player", keys: []tea.KeyMsg{runes("o")}, column: "Player ▼", want: []int{20, 30, 10}},
+		{name: "by player ascending", keys: []tea.KeyMsg{runes("o"), runes("r")}, column: "Player ▲", want: []int{10, 30, 20}},
+		{name: "by level", keys: []tea.KeyMsg{runes("o"), runes("o"), runes("o")}, column: "Level ▼", want: []int{10, 20, 30}},
+		{name: "by duration", keys: slices.Repeat([]tea.KeyMsg{runes("o")}, 4), column: "Duration ▼", want: []int{30, 20, 10}},
+		{name: "round to rank", keys: slices.Repeat([]tea.KeyMsg{runes("o")}, len(leaderboardColumns)), column: "Rank ▼", want: []int{30, 20, 10}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			board := openBoard(t, recorded)
+			press(board, test.keys...)
+
+			if column := board.columns()[board.sortColumn].Title; column != test.column {
+				t.Fatalf("sorted by %q, want %q", column, test.column)
+			}
+
+			if got := values(board.Scores); !slices.Equal(got, test.want) {
+				t.Fatalf("scores = %v, want %v", got, test.want)
+			}
+
+			// Rank stays each score's place by value whatever the order.
+			for _, score := range board.Scores {
+				if want := map[int]int{30: 1, 20: 2, 10: 3}[score.Value]; score.Rank != want {
+					t.Fatalf("score %d ranked %d, want %d", score.Value, score.Rank, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLeaderboardRows(t *testing.T) {
+	created := time.Date(2026, 3, 4, 5, 6, 0, 0, time.Local)
+	board := openBoard(t, []internal.Score{
+		{User: "bob", Session: "a", Value: 30, Level: 2, Duration: 95 * time.Second, CreatedAt: created},
+		{User: "alice", Session: "b", Value: 20, Name: "ACE", CreatedAt: created},
+	})
+
+	rows := board.table.Rows()
+	if len(rows) != 2 {
+		t.Fatalf("%d rows, want 2", len(rows))
+	}
+
+	want := []string{"1", "bob", "30", "2", "1:35", "Mar 4 2026 05:06", "-"}
+	if !slices.Equal(rows[0], want) {
+		t.Fatalf("row = %q, want %q", rows[0], want)
+	}
+
+	if player := rows[1][1]; player != currentPlayerTag+"ACE" {
+		t.Fatalf("current player's entry = %q, want it tagged and under the name they entered", player)
+	}
+
+	if board.table.Cursor() != 1 {
+		t.Fatalf("cursor on row %d, want the current player's row 1", board.table.Cursor())
+	}
+
+	if view := board.View(); strings.Contains(view, "time.Date") {
+		t.Fatalf("view renders Go syntax:\n%s", view)
+	}
+}
+
+func TestLeaderboardDropsStaleLoads(t *testing.T) {
+	sessions := internal.NewSessionManager()
+	scores := internal.NewInMemoryScoreService("alice", sessions)
+	app := &internal.App{Guest: true, Sessions: sessions, Scores: scores, Settings: internal.DefaultSettings()}
+
+	board := NewLeaderboardModel(DefaultLeaderboardConfig(app))
+	stale := board.Init()()
+
+	if _, err := scores.ImportScores(context.Background(), []internal.Score{{User: "alice", Session: "a", Value: 10}}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, fresh := board.Update(runes("r"))
+	board.Update(fresh())
+	board.Update(stale)
+
+	if !slices.Equal(values(board.Scores), []int{10}) {
+		t.Fatalf("scores = %v, want the newer load's [10]", values(board.Scores))
+	}
+}
+
+// queuedScores is a store with submissions waiting for a leaderboard server.
+type queuedScores struct {
+	*internal.InMemoryScoreService
+	pending int
+}
+
+func (q queuedScores) PendingSubmissions() int {
+	return q.pending
+}
+
+func TestLeaderboardShowsPendingSubmissions(t *testing.T) {
+	tests := []struct {
+		pending int
+		want    string
+	}{
+		{pending: 0},
+		{pending: 1, want: "1 score is waiting to reach the leaderboard server"},
+		{pending: 3, want: "3 scores are waiting to reach the leaderboard server"},
+	}
+
+	for _, test := range tests {
+		sessions := internal.NewSessionManager()
+		scores := queuedScores{internal.NewInMemoryScoreService("alice", sessions), test.pending}
+		app := &internal.App{Guest: true, Sessions: sessions, Scores: scores, Settings: internal.DefaultSettings()}
+
+		board := NewLeaderboardModel(DefaultLeaderboardConfig(app))
+		board.Update(board.Init()())
+
+		view := board.View()
+		if test.want == "" && strings.Contains(view, "waiting to reach") || !strings.Contains(view, test.want) {
+			t.Fatalf("%d pending, view:\n%s", test.pending, view)
+		}
+	}
+}
+
+func runes(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func values(scores []internal.Score) []int {
+	values := make([]int, 0, len(scores))
+	for _, score := range scores {
+		values = append(values, score.Value)
+	}
+
+	return values
+}
@@ -3,10 +3,15 @@ package menu
 import (
 	"context"
 	"fmt"
+	"strconv"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/the-Jinxist/golang_snake_game/internal"
+	"github.com/the-Jinxist/golang_snake_game/tui/game"
+	"github.com/the-Jinxist/golang_snake_game/tui/keys"
+	"github.com/the-Jinxist/golang_snake_game/tui/theme"
 	"github.com/the-Jinxist/golang_snake_game/tui/views"
 )
 
@@ -29,51 +34,153 @@ const (
 
 var _ tea.Model = StartGameModel{}
 
+// styles are the screen's lipgloss styles in the player's theme.
+type styles struct {
+	active lipgloss.Style
+	error  lipgloss.Style
+	help   lipgloss.Style
+}
+
+func newStyles(t theme.Theme) styles {
+	return styles{
+		active: lipgloss.NewStyle().Foreground(t.Accent),
+		error:  lipgloss.NewStyle().Foreground(t.Error),
+		help:   lipgloss.NewStyle().Foreground(t.Muted),
+	}
+}
+
 type StartGameModel struct {
+	app     *internal.App
 	choices []string // items on the to-do list
 	cursor  int
+	keyMap  keys.KeyMap
+	theme   theme.Theme
+	styles  styles
+
+	// The scores shown under the title, loaded by Init since the high
+	// score can come from a leaderboard server.
+	best    string
+	high    string
+	bestErr error
+	highErr error
+}
+
+// scoresLoadedMsg carries the scores shown under the title.
+type scoresLoadedMsg struct {
+	best    string
+	high    string
+	bestErr error
+	highErr error
 }
 
-func InitalModel() StartGameModel {
+const (
+	choiceContinue     = "Continue"
+	choiceStartGame    = "Start Game"
+	choiceLeaderboard  = "Leaderboard"
+	choicePlayer       = "Switch Player"
+	choiceStats        = "Stats"
+	choiceAchievements = "Achievements"
+	choiceSettings     = "Settings"
+	choiceExit         = "Exit"
+)
+
+func InitalModel(app *internal.App) StartGameModel {
+	choices := []string{choiceStartGame, choiceLeaderboard}
+
+	// Stats, achievements and profiles only exist when the storage backend
+	// keeps them; guests and the JSON store have none.
+	if app.Stats != nil {
+		choices = append(choices, choiceStats)
+	}
+
+	if app.Achievements != nil {
+		choices = append(choices, choiceAchievements)
+	}
+
+	if app.Players != nil {
+		choices = append(choices, choicePlayer)
+	}
+
+	// Guests cannot write a settings file.
+	if !app.Guest {
+		choices = append(choices, choiceSettings)
+	}
+
+	choices = append(choices, choiceExit)
+
+	// Guests have no data directory to keep a saved game in.
+	if !app.Guest && game.HasSavedGame(app) {
+		choices = append([]string{choiceContinue}, choices...)
+	}
+
+	palette := theme.FromApp(app)
+
 	return StartGameModel{
-		choices: []string{"Start Game", "Leaderboard", "Exit"},
+		app:     app,
+		choices: choices,
 		cursor:  0,
+		keyMap:  keys.FromSettings(app.Settings),
+		theme:   palette,
+		styles:  newStyles(palette),
+		best:    "…",
+		high:    "…",
 	}
 }
 
 func (m StartGameModel) Init() tea.Cmd {
-	return nil
+	return m.loadScores
+}
+
+func (m StartGameModel) loadScores() tea.Msg {
+	var msg scoresLoadedMsg
+	msg.best, msg.bestErr = m.personalBest()
+	msg.high, msg.highErr = m.highScore()
+	return msg
 }
 
 func (m StartGameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
+	case scoresLoadedMsg:
+		m.best, m.bestErr = msg.best, msg.bestErr
+		m.high, m.highErr = msg.high, msg.highErr
+
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c", "q":
+		switch {
+		case key.Matches(msg, m.keyMap.Quit):
 
 			return m, tea.Quit
-		case "A", "w":
+		case key.Matches(msg, m.keyMap.Up):
 			nextCursor := m.cursor - 1
 			if nextCursor < 0 {
 				nextCursor = 0
 			}
 
 			m.cursor = nextCursor
-		case "B", "s":
+		case key.Matches(msg, m.keyMap.Down):
 			nextCursor := m.cursor + 1
-			if nextCursor > 2 {
+			if nextCursor > len(m.choices)-1 {
 				nextCursor = 0
 			}
 
 			m.cursor = nextCursor
-		case "enter", " ":
+		case key.Matches(msg, m.keyMap.Select):
 
-			if m.cursor == 1 {
+			switch m.choices[m.cursor] {
+			case choiceContinue:
+				fmt.Print("\033[H\033[2J")
+				return m, tea.Batch(views.SwitchModeCmd(views.ModeContinue))
+			case choiceLeaderboard:
 				return m, tea.Batch(views.ClearScreen(), views.SwitchModeCmd(views.ModeLeaderboard))
-			}
-
-			if m.cursor == 2 {
+			case choiceStats:
+				return m, tea.Batch(views.ClearScreen(), views.SwitchModeCmd(views.ModeStats))
+			case choiceAchievements:
+				return m, tea.Batch(views.ClearScreen(), views.SwitchModeCmd(views.ModeAchievements))
+			case choicePlayer:
+				return m, tea.Batch(views.ClearScreen(), views.SwitchModeCmd(views.ModeProfile))
+			case choiceSettings:
+				return m, tea.Batch(views.ClearScreen(), views.SwitchModeCmd(views.ModeSettings))
+			case choiceExit:
 				return m, tea.Quit
 			}
 
@@ -92,9 +199,26 @@ func (m StartGameModel) View() string {
 		Width(30).
 		Height(5)
 
-	title := combinedTitle
+	title := m.theme.Banner(combinedTitle)
 	title += "\n"
-	title += style.Render(fmt.Sprintf("Your current highscore is: %d", getHighScore()))
+	player := m.app.Scores.GetCurrentUser()
+	if m.app.Guest {
+		player += " (nothing is saved)"
+	}
+
+	title += style.Width(50).Render(fmt.Sprintf(
+		"Playing as: %s\nYour best: %s · All-time highscore: %s",
+		player, m.best, m.high,
+	))
+
+	switch {
+	case m.app.StorageErr != nil:
+		title += "\n" + m.styles.error.Render(fmt.Sprintf("The score store is unavailable, nothing will be saved: %s", m.app.StorageErr)) + "\n"
+	case m.highErr != nil:
+		title += "\n" + m.styles.error.Render(fmt.Sprintf("Could not load scores: %s", m.highErr)) + "\n"
+	case m.bestErr != nil:
+		title += "\n" + m.styles.error.Render(fmt.Sprintf("Could not load scores: %s", m.bestErr)) + "\n"
+	}
 
 	options := ""
 
@@ -102,25 +226,34 @@ func (m StartGameModel) View() string {
 
 		prefix := ""
 		if index == m.cursor {
-			prefix = lipgloss.NewStyle().Foreground(lipgloss.Color("#3297a8")).Render("> ")
+			prefix = m.styles.active.Render("> ")
 		}
 
 		options += style.Render(fmt.Sprintf("\n%s%s", prefix, value))
 	}
 
-	help := "\n[INSTRUCTIONS]:\n· ↑ or W to move up\n· ↓ or S to move down\n· ENTER to select option"
-	help = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#444745")).
-		Render(help)
+	help := keys.Instructions(m.keyMap.Up, m.keyMap.Down, keys.Describe("select option", m.keyMap.Select))
+	help = m.styles.help.Render(help)
 
 	return title + options + help
 }
 
-func getHighScore() int {
-	score, err := internal.GetScoreService().GetHighScore(context.Background())
+// highScore renders the all-time high score, or "?" if it cannot be read.
+func (m StartGameModel) highScore() (string, error) {
+	score, err := m.app.Scores.GetHighScore(context.Background())
+	if err != nil {
+		return "?", err
+	}
+
+	return strconv.Itoa(score.Value), nil
+}
+
+// personalBest renders the player's best score, or "?" if it cannot be read.
+func (m StartGameModel) personalBest() (string, error) {
+	score, err := m.app.Scores.GetPersonalBest(context.Background())
 	if err != nil {
-		return 0
+		return "?", err
 	}
 
-	return score.Value
+	return strconv.Itoa(score.Value), nil
 }
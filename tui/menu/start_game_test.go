@@ -0,0 +1,56 @@
+package menu
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/the-Jinxist/golang_snake_game/internal"
+)
+
+func TestStartGameScores(t *testing.T) {
+	tests := []struct {
+		name   string
+		scores []internal.Score
+		want   string
+	}{
+		{name: "no scores yet", want: "Your best: 0 · All-time highscore: 0"},
+		{
+			name:   "only someone else has played",
+			scores: []internal.Score{{User: "bob", Session: "b1", Value: 90}},
+			want:   "Your best: 0 · All-time highscore: 90",
+		},
+		{
+			name: "both have played",
+			scores: []internal.Score{
+				{User: "alice", Session: "a1", Value: 40},
+				{User: "alice", Session: "a2", Value: 20},
+				{User: "bob", Session: "b1", Value: 90},
+			},
+			want: "Your best: 40 · All-time highscore: 90",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sessions := internal.NewSessionManager()
+			scores := internal.NewInMemoryScoreService("alice", sessions)
+			if _, err := scores.ImportScores(context.Background(), test.scores); err != nil {
+				t.Fatal(err)
+			}
+
+			app := &internal.App{Guest: true, Sessions: sessions, Scores: scores, Settings: internal.DefaultSettings()}
+			model := InitalModel(app)
+
+			// Scores are loaded by Init rather than read while rendering.
+			if view := model.View(); strings.Contains(view, test.want) {
+				t.Fatalf("scores shown before they were loaded:\n%s", view)
+			}
+
+			updated, _ := model.Update(model.Init()())
+			if view := updated.View(); !strings.Contains(view, test.want) {
+				t.Fatalf("view does not show %q:\n%s", test.want, view)
+			}
+		})
+	}
+}
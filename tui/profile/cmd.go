@@ -0,0 +1,25 @@
+package profile
+
+import (
+	"github.com/the-Jinxist/golang_snake_game/internal"
+	"github.com/the-Jinxist/golang_snake_game/tui/keys"
+	"github.com/the-Jinxist/golang_snake_game/tui/theme"
+)
+
+type ProfileConfig struct {
+	PlayerService internal.PlayerService
+	ScoreService  internal.ScoreService
+	SelectPlayer  func(name string) error
+	Keys          keys.KeyMap
+	Theme         theme.Theme
+}
+
+func DefaultProfileConfig(app *internal.App) ProfileConfig {
+	return ProfileConfig{
+		PlayerService: app.Players,
+		ScoreService:  app.Scores,
+		SelectPlayer:  app.SetActivePlayer,
+		Keys:          keys.FromSettings(app.Settings),
+		Theme:         theme.FromApp(app),
+	}
+}
@@ -0,0 +1,185 @@
+package profile
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/the-Jinxist/golang_snake_game/internal"
+	"github.com/the-Jinxist/golang_snake_game/tui/keys"
+	"github.com/the-Jinxist/golang_snake_game/tui/theme"
+	"github.com/the-Jinxist/golang_snake_game/tui/views"
+)
+
+const newPlayerChoice = "+ New player"
+
+var (
+	_ tea.Model           = &ProfilePicker{}
+	_ views.InputCapturer = &ProfilePicker{}
+)
+
+// styles are the screen's lipgloss styles in the player's theme.
+type styles struct {
+	active lipgloss.Style
+	error  lipgloss.Style
+	help   lipgloss.Style
+}
+
+func newStyles(t theme.Theme) styles {
+	return styles{
+		active: lipgloss.NewStyle().Foreground(t.Accent),
+		error:  lipgloss.NewStyle().Foreground(t.Error),
+		help:   lipgloss.NewStyle().Foreground(t.Muted),
+	}
+}
+
+type ProfilePicker struct {
+	Config   ProfileConfig
+	Players  []internal.Player
+	cursor   int
+	creating bool
+	input    textinput.Model
+	err      error
+	styles   styles
+}
+
+func NewProfileModel(config ProfileConfig) *ProfilePicker {
+	players, err := config.PlayerService.GetPlayers(context.Background())
+
+	input := textinput.New()
+	input.Placeholder = "Your name"
+	input.CharLimit = 20
+	input.Width = 20
+
+	return &ProfilePicker{
+		Config:  config,
+		Players: players,
+		input:   input,
+		err:     err,
+		styles:  newStyles(config.Theme),
+	}
+}
+
+// IsCapturingInput implements views.InputCapturer.
+func (p *ProfilePicker) IsCapturingInput() bool {
+	return p.creating
+}
+
+// Init implements tea.Model.
+func (p *ProfilePicker) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model.
+func (p *ProfilePicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return p, nil
+	}
+
+	if p.creating {
+		return p.updateNewPlayer(keyMsg)
+	}
+
+	switch {
+	case key.Matches(keyMsg, p.Config.Keys.Back):
+		return p, tea.Batch(views.ClearScreen(), views.SwitchModeCmd(views.ModeMenu))
+	case key.Matches(keyMsg, p.Config.Keys.Up):
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case key.Matches(keyMsg, p.Config.Keys.Down):
+		if p.cursor < len(p.Players) {
+			p.cursor++
+		}
+	case key.Matches(keyMsg, p.Config.Keys.Select):
+		if p.cursor == len(p.Players) {
+			p.creating = true
+			p.err = nil
+			p.input.Reset()
+			return p, p.input.Focus()
+		}
+
+		return p.selectPlayer(p.Players[p.cursor].Name)
+	}
+
+	return p, nil
+}
+
+func (p *ProfilePicker) updateNewPlayer(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, keys.Cancel):
+		p.creating = false
+		p.input.Blur()
+		return p, nil
+	case key.Matches(msg, keys.Submit):
+		player, err := p.Config.PlayerService.CreatePlayer(context.Background(), p.input.Value())
+		if err != nil {
+			p.err = err
+			return p, nil
+		}
+
+		return p.selectPlayer(player.Name)
+	}
+
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	return p, cmd
+}
+
+func (p *ProfilePicker) selectPlayer(name string) (tea.Model, tea.Cmd) {
+	if err := p.Config.SelectPlayer(name); err != nil {
+		p.err = err
+		return p, nil
+	}
+
+	return p, tea.Batch(views.ClearScreen(), views.SwitchModeCmd(views.ModeMenu))
+}
+
+// View implements tea.Model.
+func (p *ProfilePicker) View() string {
+	current := p.Config.ScoreService.GetCurrentUser()
+
+	view := "\nWHO'S PLAYING?\n\n"
+
+	for index, player := range p.Players {
+		view += p.choiceView(index, player.Name)
+		if player.Name == current {
+			view += p.styles.active.Render("  (current)")
+		}
+		view += "\n"
+	}
+
+	view += p.choiceView(len(p.Players), newPlayerChoice) + "\n"
+
+	if p.creating {
+		view += "\n" + p.input.View() + "\n"
+	}
+
+	if p.err != nil {
+		view += "\n" + p.styles.error.Render(p.err.Error()) + "\n"
+	}
+
+	help := keys.Instructions(
+		p.Config.Keys.Up,
+		p.Config.Keys.Down,
+		keys.Describe("select player", p.Config.Keys.Select),
+		p.Config.Keys.Back,
+	)
+	if p.creating {
+		help = keys.Instructions(keys.Describe("create player", keys.Submit), keys.Cancel)
+	}
+
+	return view + p.styles.help.Render(help)
+}
+
+func (p *ProfilePicker) choiceView(index int, label string) string {
+	if index == p.cursor {
+		return fmt.Sprintf("%s%s", p.styles.active.Render("> "), label)
+	}
+
+	return "  " + label
+}
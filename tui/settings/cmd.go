@@ -0,0 +1,41 @@
+package settings
+
+import (
+	"context"
+	"errors"
+
+	"github.com/the-Jinxist/golang_snake_game/internal"
+	"github.com/the-Jinxist/golang_snake_game/tui/keys"
+	"github.com/the-Jinxist/golang_snake_game/tui/skin"
+	"github.com/the-Jinxist/golang_snake_game/tui/theme"
+)
+
+type SettingsConfig struct {
+	Settings   internal.Settings
+	LoadErr    error
+	Save       func(settings internal.Settings) error
+	Themes     []string
+	KeyPresets []string
+	Keys       keys.KeyMap
+	Theme      theme.Theme
+	// Skins are the skins the player's best has unlocked, and NextSkin
+	// describes the next one to earn.
+	Skins    []string
+	NextSkin string
+}
+
+func DefaultSettingsConfig(app *internal.App) SettingsConfig {
+	best, _ := app.Scores.GetPersonalBest(context.Background())
+
+	return SettingsConfig{
+		Settings:   app.Settings,
+		LoadErr:    errors.Join(app.SettingsErr, keys.CheckOverrides(app.Settings)),
+		Save:       app.SaveSettings,
+		Themes:     theme.Names(),
+		KeyPresets: keys.Presets,
+		Skins:      skin.Unlocked(best.Value),
+		NextSkin:   skin.NextUnlock(best.Value),
+		Keys:       keys.FromSettings(app.Settings),
+		Theme:      theme.FromApp(app),
+	}
+}
@@ -0,0 +1,237 @@
+package settings
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/the-Jinxist/golang_snake_game/internal"
+	"github.com/the-Jinxist/golang_snake_game/tui/keys"
+	"github.com/the-Jinxist/golang_snake_game/tui/theme"
+	"github.com/the-Jinxist/golang_snake_game/tui/views"
+)
+
+var _ tea.Model = &SettingsModel{}
+
+// setting is one row of the screen. change moves its value one step forward
+// or back through the choices.
+type setting struct {
+	label       string
+	description string
+	value       func(s internal.Settings) string
+	change      func(s *internal.Settings, step int)
+}
+
+// styles are the screen's lipgloss styles in the player's theme.
+type styles struct {
+	active lipgloss.Style
+	value  lipgloss.Style
+	desc   lipgloss.Style
+	error  lipgloss.Style
+	help   lipgloss.Style
+}
+
+func newStyles(t theme.Theme) styles {
+	return styles{
+		active: lipgloss.NewStyle().Foreground(t.Accent),
+		value:  lipgloss.NewStyle().Bold(true),
+		desc:   lipgloss.NewStyle().Italic(true).Foreground(t.Text),
+		error:  lipgloss.NewStyle().Foreground(t.Error),
+		help:   lipgloss.NewStyle().Foreground(t.Muted),
+	}
+}
+
+type SettingsModel struct {
+	Config   SettingsConfig
+	Settings internal.Settings
+	rows     []setting
+	cursor   int
+	err      error
+	errVerb  string
+	styles   styles
+}
+
+func NewSettingsModel(config SettingsConfig) *SettingsModel {
+	return &SettingsModel{
+		Config:   config,
+		Settings: config.Settings,
+		rows:     settingRows(config),
+		err:      config.LoadErr,
+		errVerb:  "read",
+		styles:   newStyles(config.Theme),
+	}
+}
+
+func settingRows(config SettingsConfig) []setting {
+	return []setting{
+		{
+			label:       "Speed",
+			description: "How fast the snake moves on every board",
+			value:       func(s internal.Settings) string { return string(s.Speed) },
+			change: func(s *internal.Settings, step int) {
+				s.Speed = cycle(internal.Speeds, s.Speed, step)
+			},
+		},
+		{
+			label:       "Warm-up walls",
+			description: "Wall in the warm-up board games start on; levels keep their own walls",
+			value:       func(s internal.Settings) string { return onOff(s.WarmUpWalls) },
+			change:      func(s *internal.Settings, step int) { s.WarmUpWalls = !s.WarmUpWalls },
+		},
+		{
+			label:       "Theme",
+			description: "Colours of every screen, the board and the snake",
+			value:       func(s internal.Settings) string { return s.Theme },
+			change: func(s *internal.Settings, step int) {
+				s.Theme = cycle(config.Themes, s.Theme, step)
+			},
+		},
+		{
+			label:       "Skin",
+			description: skinDescription(config.NextSkin),
+			value:       func(s internal.Settings) string { return s.Skin },
+			change: func(s *internal.Settings, step int) {
+				s.Skin = cycle(config.Skins, s.Skin, step)
+			},
+		},
+		{
+			label:       "Key bindings",
+			description: "Which keys steer the snake",
+			value:       func(s internal.Settings) string { return s.Keys },
+			change: func(s *internal.Settings, step int) {
+				s.Keys = cycle(config.KeyPresets, s.Keys, step)
+			},
+		},
+		{
+			label:       "Show help",
+			description: "List the controls under the board while playing",
+			value:       func(s internal.Settings) string { return onOff(s.ShowHelp) },
+			change:      func(s *internal.Settings, step int) { s.ShowHelp = !s.ShowHelp },
+		},
+		{
+			label:       "Sound",
+			description: "Ring the terminal bell when the snake eats or dies",
+			value:       func(s internal.Settings) string { return onOff(s.Sound) },
+			change:      func(s *internal.Settings, step int) { s.Sound = !s.Sound },
+		},
+	}
+}
+
+// Init implements tea.Model.
+func (m *SettingsModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model.
+func (m *SettingsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	keyMap := m.Config.Keys
+
+	switch {
+	case key.Matches(keyMsg, keyMap.Back):
+		return m, tea.Batch(views.ClearScreen(), views.SwitchModeCmd(views.ModeMenu))
+	case key.Matches(keyMsg, keyMap.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case key.Matches(keyMsg, keyMap.Down):
+		if m.cursor < len(m.rows)-1 {
+			m.cursor++
+		}
+	case key.Matches(keyMsg, keyMap.Left):
+		m.change(-1)
+	case key.Matches(keyMsg, keyMap.Right, keyMap.Select):
+		m.change(1)
+	}
+
+	return m, nil
+}
+
+// change steps the selected setting and saves straight away, so there is
+// nothing to lose by leaving the screen.
+func (m *SettingsModel) change(step int) {
+	settings := m.Settings
+	m.rows[m.cursor].change(&settings, step)
+
+	if err := m.Config.Save(settings); err != nil {
+		m.err, m.errVerb = err, "save"
+		return
+	}
+
+	// The screen keeps a theme given with --theme until another one is
+	// picked here.
+	if settings.Theme != m.Settings.Theme {
+		m.Config.Theme = theme.FromSettings(settings)
+		m.styles = newStyles(m.Config.Theme)
+	}
+
+	m.Settings = settings
+	m.Config.Keys = keys.FromSettings(settings)
+	m.err = nil
+}
+
+// View implements tea.Model.
+func (m *SettingsModel) View() string {
+	view := "\nSETTINGS\n\n"
+
+	for index, row := range m.rows {
+		prefix := "  "
+		if index == m.cursor {
+			prefix = m.styles.active.Render("> ")
+		}
+
+		view += fmt.Sprintf("%s%-16s %s\n", prefix, row.label, m.styles.value.Render("‹ "+row.value(m.Settings)+" ›"))
+	}
+
+	view += "\n" + m.styles.desc.Render(m.rows[m.cursor].description) + "\n"
+
+	if m.err != nil {
+		view += "\n" + m.styles.error.Render(fmt.Sprintf("Could not %s settings: %s", m.errVerb, m.err)) + "\n"
+	}
+
+	help := keys.Instructions(
+		m.Config.Keys.Up,
+		m.Config.Keys.Down,
+		keys.Describe("change a setting", m.Config.Keys.Left, m.Config.Keys.Right, m.Config.Keys.Select),
+		m.Config.Keys.Back,
+	)
+	return view + m.styles.help.Render(help)
+}
+
+func skinDescription(next string) string {
+	description := "How the snake is drawn; more skins unlock as your best score grows"
+	if next != "" {
+		description += " (" + next + ")"
+	}
+
+	return description
+}
+
+// cycle returns the choice step places away from current, wrapping around.
+// A current value that is not a choice moves to the first one.
+func cycle[T comparable](choices []T, current T, step int) T {
+	if len(choices) == 0 {
+		return current
+	}
+
+	index := slices.Index(choices, current)
+	if index < 0 {
+		return choices[0]
+	}
+
+	return choices[(index+step+len(choices))%len(choices)]
+}
+
+func onOff(value bool) string {
+	if value {
+		return "on"
+	}
+
+	return "off"
+}
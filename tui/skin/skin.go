@@ -0,0 +1,245 @@
+// Package skin holds the snake skins a player unlocks as their personal best
+// grows. A skin colours each segment by its place along the body and draws
+// the bends and tail with their own glyphs.
+package skin
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/the-Jinxist/golang_snake_game/internal"
+	"github.com/the-Jinxist/golang_snake_game/tui/theme"
+)
+
+// DefaultSkin is always unlocked, and used when the settings name a skin
+// that does not exist or is still locked.
+const DefaultSkin = "classic"
+
+// Shape is how a body segment joins its neighbours. Corner shapes are named
+// for the two sides they join, e.g. UpRight joins the segment above to the
+// one on the right.
+type Shape int
+
+const (
+	Horizontal Shape = iota
+	Vertical
+	UpRight
+	UpLeft
+	DownRight
+	DownLeft
+	Tail
+)
+
+// glyphs are the two-column cells a skin draws each shape with.
+type glyphs map[Shape]string
+
+var (
+	// pipes keep the vertical stroke in the left column, so corners into the
+	// left neighbour end there and meet its horizontal stroke.
+	pipes = glyphs{
+		Horizontal: "━━",
+		Vertical:   "┃ ",
+		UpRight:    "┗━",
+		UpLeft:     "┛ ",
+		DownRight:  "┏━",
+		DownLeft:   "┓ ",
+	}
+	rounded = glyphs{
+		Horizontal: "──",
+		Vertical:   "│ ",
+		UpRight:    "╰─",
+		UpLeft:     "╯ ",
+		DownRight:  "╭─",
+		DownLeft:   "╮ ",
+	}
+)
+
+// Skin is one look for the snake.
+type Skin struct {
+	Name string
+	// Unlock is the personal best needed to use the skin.
+	Unlock int
+	// Colors run from head to tail. With Cycle set they repeat along the
+	// body instead; with none the theme's snake colour is used.
+	Colors []lipgloss.Color
+	Cycle  bool
+	// body draws each shape; nil fills every segment with the theme's filled
+	// cell.
+	body glyphs
+	tail string
+}
+
+// Skins lists every skin in unlock order, which is also the order the
+// settings screen cycles them.
+var Skins = []Skin{
+	{
+		Name: DefaultSkin,
+		tail: "▓▓",
+	},
+	{
+		Name:   "viper",
+		Unlock: 100,
+		Colors: []lipgloss.Color{"#9BE15D", "#1E5631"},
+		body:   pipes,
+		tail:   "╸ ",
+	},
+	{
+		Name:   "coral",
+		Unlock: 700,
+		Colors: []lipgloss.Color{"#FF7F50", "#FFD1BA", "#8B1A1A"},
+		body:   rounded,
+		tail:   "· ",
+	},
+	{
+		Name:   "ember",
+		Unlock: 1900,
+		Colors: []lipgloss.Color{"#FFF3B0", "#FF8C00", "#B22222", "#3B0A0A"},
+		tail:   "░░",
+	},
+	{
+		Name:   "rainbow",
+		Unlock: 3500,
+		Colors: []lipgloss.Color{"#E81416", "#FFA500", "#FAEB36", "#79C314", "#487DE7", "#4B369D", "#70369D"},
+		Cycle:  true,
+		body:   pipes,
+		tail:   "✦ ",
+	},
+}
+
+// Get returns the skin called name, locked or not.
+func Get(name string) (Skin, bool) {
+	index := slices.IndexFunc(Skins, func(skin Skin) bool { return skin.Name == name })
+	if index < 0 {
+		return Skin{}, false
+	}
+
+	return Skins[index], true
+}
+
+// Unlocked lists the names of the skins a personal best of best has earned.
+func Unlocked(best int) []string {
+	var names []string
+	for _, skin := range Skins {
+		if skin.Unlock <= best {
+			names = append(names, skin.Name)
+		}
+	}
+
+	return names
+}
+
+// UnlockedBetween lists the skins earned by beating previous with score.
+func UnlockedBetween(previous, score int) []string {
+	var names []string
+	for _, skin := range Skins {
+		if skin.Unlock > previous && skin.Unlock <= score {
+			names = append(names, skin.Name)
+		}
+	}
+
+	return names
+}
+
+// NextUnlock describes the next skin best has not reached yet, or returns ""
+// once every skin is unlocked.
+func NextUnlock(best int) string {
+	for _, skin := range Skins {
+		if skin.Unlock > best {
+			return fmt.Sprintf("%s unlocks at a best of %d", skin.Name, skin.Unlock)
+		}
+	}
+
+	return ""
+}
+
+// FromSettings returns the player's chosen skin if their personal best has
+// unlocked it, and the default skin otherwise.
+func FromSettings(settings internal.Settings, best int) Skin {
+	if skin, ok := Get(settings.Skin); ok && skin.Unlock <= best {
+		return skin
+	}
+
+	skin, _ := Get(DefaultSkin)
+	return skin
+}
+
+// Segment renders the segment at index, counted from the head, of a snake
+// length segments long.
+func (s Skin) Segment(palette theme.Theme, shape Shape, index, length int) string {
+	return s.Paint(palette, s.glyph(palette, shape), index, length)
+}
+
+// Paint colours glyph as the segment at index would be, so the head can be
+// drawn in the same colour as the body behind it.
+func (s Skin) Paint(palette theme.Theme, glyph string, index, length int) string {
+	return lipgloss.NewStyle().Foreground(s.color(palette, index, length)).Render(glyph)
+}
+
+func (s Skin) glyph(palette theme.Theme, shape Shape) string {
+	if shape == Tail && s.tail != "" {
+		return s.tail
+	}
+
+	if glyph, ok := s.body[shape]; ok {
+		return glyph
+	}
+
+	return palette.FilledCell
+}
+
+func (s Skin) color(palette theme.Theme, index, length int) lipgloss.Color {
+	switch {
+	case len(s.Colors) == 0:
+		return palette.Snake
+	case s.Cycle:
+		return s.Colors[index%len(s.Colors)]
+	case len(s.Colors) == 1 || length < 2:
+		return s.Colors[0]
+	}
+
+	// Spread the stops evenly along the body and blend between the two
+	// either side of this segment.
+	position := float64(index) / float64(length-1) * float64(len(s.Colors)-1)
+	stop := min(int(position), len(s.Colors)-2)
+	return blend(s.Colors[stop], s.Colors[stop+1], position-float64(stop))
+}
+
+// blend mixes two #RRGGBB colours, weight 0 giving from and 1 giving to.
+// Colours in any other form are not blended.
+func blend(from, to lipgloss.Color, weight float64) lipgloss.Color {
+	a, okA := parseHex(from)
+	b, okB := parseHex(to)
+	if !okA || !okB {
+		return from
+	}
+
+	var mixed [3]int
+	for i := range mixed {
+		mixed[i] = a[i] + int(math.Round(float64(b[i]-a[i])*weight))
+	}
+
+	return lipgloss.Color(fmt.Sprintf("#%02X%02X%02X", mixed[0], mixed[1], mixed[2]))
+}
+
+func parseHex(color lipgloss.Color) ([3]int, bool) {
+	var rgb [3]int
+
+	value := string(color)
+	if len(value) != 7 || value[0] != '#' {
+		return rgb, false
+	}
+
+	for i := range rgb {
+		channel, err := strconv.ParseUint(value[1+i*2:3+i*2], 16, 8)
+		if err != nil {
+			return rgb, false
+		}
+
+		rgb[i] = int(channel)
+	}
+
+	return rgb, true
+}
@@ -0,0 +1,147 @@
+package skin
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/the-Jinxist/golang_snake_game/internal"
+	"github.com/the-Jinxist/golang_snake_game/tui/theme"
+)
+
+func TestBlend(t *testing.T) {
+	tests := []struct {
+		from, to lipgloss.Color
+		weight   float64
+		want     lipgloss.Color
+	}{
+		{from: "#000000", to: "#FFFFFF", weight: 0, want: "#000000"},
+		{from: "#000000", to: "#FFFFFF", weight: 1, want: "#FFFFFF"},
+		{from: "#000000", to: "#FFFFFF", weight: 0.5, want: "#808080"},
+		{from: "#FF0000", to: "#0000FF", weight: 0.25, want: "#BF0040"},
+		// Colours that are not #RRGGBB are left alone.
+		{from: "9", to: "#FFFFFF", weight: 0.5, want: "9"},
+		{from: "#000000", to: "red", weight: 0.5, want: "#000000"},
+		{from: "#GG0000", to: "#FFFFFF", weight: 0.5, want: "#GG0000"},
+	}
+
+	for _, test := range tests {
+		if got := blend(test.from, test.to, test.weight); got != test.want {
+			t.Errorf("blend(%s, %s, %v) = %s, want %s", test.from, test.to, test.weight, got, test.want)
+		}
+	}
+}
+
+func TestColor(t *testing.T) {
+	palette := theme.Theme{Snake: "#00FF00"}
+	gradient := Skin{Colors: []lipgloss.Color{"#000000", "#FFFFFF", "#000000"}}
+	cycle := Skin{Colors: []lipgloss.Color{"#111111", "#222222", "#333333"}, Cycle: true}
+
+	tests := []struct {
+		name          string
+		skin          Skin
+		index, length int
+		want          lipgloss.Color
+	}{
+		{name: "no colours", skin: Skin{}, index: 3, length: 5, want: "#00FF00"},
+		{name: "gradient head", skin: gradient, index: 0, length: 5, want: "#000000"},
+		{name: "gradient between stops", skin: gradient, index: 1, length: 5, want: "#808080"},
+		{name: "gradient middle stop", skin: gradient, index: 2, length: 5, want: "#FFFFFF"},
+		{name: "gradient tail", skin: gradient, index: 4, length: 5, want: "#000000"},
+		{name: "gradient on a snake of one", skin: gradient, index: 0, length: 1, want: "#000000"},
+		{name: "cycle", skin: cycle, index: 1, length: 10, want: "#222222"},
+		{name: "cycle wraps", skin: cycle, index: 4, length: 10, want: "#222222"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.skin.color(palette, test.index, test.length); got != test.want {
+				t.Fatalf("color(%d of %d) = %s, want %s", test.index, test.length, got, test.want)
+			}
+		})
+	}
+}
+
+func TestGlyph(t *testing.T) {
+	palette := theme.Theme{FilledCell: "██"}
+	viper, _ := Get("viper")
+	classic, _ := Get(DefaultSkin)
+
+	tests := []struct {
+		name  string
+		skin  Skin
+		shape Shape
+		want  string
+	}{
+		{name: "corner", skin: viper, shape: UpRight, want: "┗━"},
+		{name: "straight", skin: viper, shape: Vertical, want: "┃ "},
+		{name: "tail", skin: viper, shape: Tail, want: "╸ "},
+		{name: "skin without body glyphs", skin: classic, shape: DownLeft, want: "██"},
+		{name: "skin without body glyphs, tail", skin: classic, shape: Tail, want: "▓▓"},
+		{name: "skin without a tail", skin: Skin{body: pipes}, shape: Tail, want: "██"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.skin.glyph(palette, test.shape); got != test.want {
+				t.Fatalf("glyph(%d) = %q, want %q", test.shape, got, test.want)
+			}
+		})
+	}
+}
+
+func TestUnlocks(t *testing.T) {
+	tests := []struct {
+		best     int
+		unlocked []string
+		next     string
+	}{
+		{best: 0, unlocked: []string{"classic"}, next: "viper unlocks at a best of 100"},
+		{best: 99, unlocked: []string{"classic"}, next: "viper unlocks at a best of 100"},
+		{best: 100, unlocked: []string{"classic", "viper"}, next: "coral unlocks at a best of 700"},
+		{best: 1900, unlocked: []string{"classic", "viper", "coral", "ember"}, next: "rainbow unlocks at a best of 3500"},
+		{best: 5000, unlocked: []string{"classic", "viper", "coral", "ember", "rainbow"}},
+	}
+
+	for _, test := range tests {
+		if got := Unlocked(test.best); !slices.Equal(got, test.unlocked) {
+			t.Errorf("Unlocked(%d) = %v, want %v", test.best, got, test.unlocked)
+		}
+
+		if got := NextUnlock(test.best); got != test.next {
+			t.Errorf("NextUnlock(%d) = %q, want %q", test.best, got, test.next)
+		}
+	}
+
+	if got := UnlockedBetween(99, 700); !slices.Equal(got, []string{"viper", "coral"}) {
+		t.Errorf("UnlockedBetween(99, 700) = %v, want viper and coral", got)
+	}
+
+	if got := UnlockedBetween(100, 699); len(got) != 0 {
+		t.Errorf("UnlockedBetween(100, 699) = %v, want none", got)
+	}
+}
+
+func TestFromSettings(t *testing.T) {
+	tests := []struct {
+		name string
+		skin string
+		best int
+		want string
+	}{
+		{name: "unlocked", skin: "coral", best: 700, want: "coral"},
+		{name: "still locked", skin: "coral", best: 699, want: DefaultSkin},
+		{name: "does not exist", skin: "plaid", best: 5000, want: DefaultSkin},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			settings := internal.DefaultSettings()
+			settings.Skin = test.skin
+
+			if got := FromSettings(settings, test.best); got.Name != test.want {
+				t.Fatalf("FromSettings(%s, best %d) = %s, want %s", test.skin, test.best, got.Name, test.want)
+			}
+		})
+	}
+}
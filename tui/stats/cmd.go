@@ -0,0 +1,23 @@
+package stats
+
+import (
+	"github.com/the-Jinxist/golang_snake_game/internal"
+	"github.com/the-Jinxist/golang_snake_game/tui/keys"
+	"github.com/the-Jinxist/golang_snake_game/tui/theme"
+)
+
+type StatsConfig struct {
+	StatsStore   internal.StatsStore
+	ScoreService internal.ScoreService
+	Keys         keys.KeyMap
+	Theme        theme.Theme
+}
+
+func DefaultStatsConfig(app *internal.App) StatsConfig {
+	return StatsConfig{
+		StatsStore:   app.Stats,
+		ScoreService: app.Scores,
+		Keys:         keys.FromSettings(app.Settings),
+		Theme:        theme.FromApp(app),
+	}
+}
@@ -0,0 +1,133 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/the-Jinxist/golang_snake_game/internal"
+	"github.com/the-Jinxist/golang_snake_game/tui/keys"
+	"github.com/the-Jinxist/golang_snake_game/tui/theme"
+	"github.com/the-Jinxist/golang_snake_game/tui/views"
+)
+
+const recentGames = 20
+
+var _ tea.Model = &StatsModel{}
+
+// styles are the screen's lipgloss styles in the player's theme.
+type styles struct {
+	label     lipgloss.Style
+	value     lipgloss.Style
+	sparkline lipgloss.Style
+	error     lipgloss.Style
+	help      lipgloss.Style
+}
+
+func newStyles(t theme.Theme) styles {
+	return styles{
+		label:     lipgloss.NewStyle().Width(18).Foreground(t.Subtle),
+		value:     lipgloss.NewStyle().Bold(true),
+		sparkline: lipgloss.NewStyle().Foreground(t.Accent),
+		error:     lipgloss.NewStyle().Foreground(t.Error),
+		help:      lipgloss.NewStyle().Foreground(t.Muted),
+	}
+}
+
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+type StatsModel struct {
+	Config StatsConfig
+	User   string
+	Stats  internal.PlayerStats
+	err    error
+	styles styles
+}
+
+func NewStatsModel(config StatsConfig) *StatsModel {
+	user := config.ScoreService.GetCurrentUser()
+	stats, err := config.StatsStore.GetPlayerStats(context.Background(), user, recentGames)
+
+	return &StatsModel{
+		Config: config,
+		User:   user,
+		Stats:  stats,
+		err:    err,
+		styles: newStyles(config.Theme),
+	}
+}
+
+// Init implements tea.Model.
+func (s *StatsModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model.
+func (s *StatsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		if key.Matches(msg, s.Config.Keys.Back) {
+			return s, tea.Batch(views.ClearScreen(), views.SwitchModeCmd(views.ModeMenu))
+		}
+	}
+
+	return s, nil
+}
+
+// View implements tea.Model.
+func (s *StatsModel) View() string {
+	view := fmt.Sprintf("\nSTATS FOR %s\n\n", strings.ToUpper(s.User))
+
+	if s.err != nil {
+		view += s.styles.error.Render(fmt.Sprintf("Could not load stats: %s", s.err)) + "\n"
+	}
+
+	view += s.row("Games played", fmt.Sprintf("%d", s.Stats.GamesPlayed))
+	view += s.row("Food eaten", fmt.Sprintf("%d", s.Stats.FoodEaten))
+	view += s.row("Average score", fmt.Sprintf("%.0f", s.Stats.AverageScore))
+	view += s.row("Best score", fmt.Sprintf("%d", s.Stats.BestScore))
+	view += s.row("Longest snake", fmt.Sprintf("%d", s.Stats.LongestSnake))
+	view += s.row("Total play time", s.Stats.PlayTime.Round(time.Second).String())
+
+	view += "\n"
+	view += s.row("Hit a wall", fmt.Sprintf("%d", s.Stats.Deaths[internal.DeathWall]))
+	view += s.row("Hit a pillar", fmt.Sprintf("%d", s.Stats.Deaths[internal.DeathPillar]))
+	view += s.row("Ate yourself", fmt.Sprintf("%d", s.Stats.Deaths[internal.DeathSelf]))
+
+	view += "\n"
+	view += s.row("Recent scores", s.styles.sparkline.Render(sparkline(s.Stats.RecentScores)))
+
+	return view + s.styles.help.Render(fmt.Sprintf("\nPress [%s] to return back to menu screen", keys.Label(s.Config.Keys.Back)))
+}
+
+func (s *StatsModel) row(label, value string) string {
+	return s.styles.label.Render(label) + s.styles.value.Render(value) + "\n"
+}
+
+// sparkline renders values as a row of block characters scaled to the largest
+// value.
+func sparkline(values []int) string {
+	if len(values) == 0 {
+		return "no games yet"
+	}
+
+	highest := 0
+	for _, value := range values {
+		highest = max(highest, value)
+	}
+
+	var line strings.Builder
+	for _, value := range values {
+		index := 0
+		if highest > 0 {
+			index = value * (len(sparkBars) - 1) / highest
+		}
+
+		line.WriteRune(sparkBars[index])
+	}
+
+	return line.String()
+}
@@ -3,74 +3,117 @@ package tui
 import (
 	"context"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/the-Jinxist/golang_snake_game/internal"
+	"github.com/the-Jinxist/golang_snake_game/tui/achievements"
 	"github.com/the-Jinxist/golang_snake_game/tui/game"
+	"github.com/the-Jinxist/golang_snake_game/tui/keys"
 	"github.com/the-Jinxist/golang_snake_game/tui/leaderboard"
 	"github.com/the-Jinxist/golang_snake_game/tui/menu"
+	"github.com/the-Jinxist/golang_snake_game/tui/profile"
+	"github.com/the-Jinxist/golang_snake_game/tui/settings"
+	"github.com/the-Jinxist/golang_snake_game/tui/stats"
+	"github.com/the-Jinxist/golang_snake_game/tui/theme"
 	"github.com/the-Jinxist/golang_snake_game/tui/views"
 )
 
 type SuperSnake struct {
+	app   *internal.App
 	child tea.Model
 
 	width  int
 	height int
 }
 
-var (
-	startMenu menu.StartGameModel
-)
-
-func NewModel() *SuperSnake {
-	startMenu = menu.InitalModel()
+// NewModel returns the root model of a game running against app.
+func NewModel(app *internal.App) *SuperSnake {
 	return &SuperSnake{
-		child: startMenu,
+		app:   app,
+		child: menu.InitalModel(app),
 	}
 }
 
 func (s *SuperSnake) setChild(mode views.Mode) {
 	switch mode {
 	case views.ModeGame:
-		s.child = game.InitalGameModel(game.DefaultGameConfig())
+		s.child = game.InitalGameModel(game.DefaultGameConfig(s.app))
 		return
 	case views.ModeLeaderboard:
 		s.child = leaderboard.NewLeaderboardModel(
-			leaderboard.DefaultLeaderboardConfig(),
+			leaderboard.DefaultLeaderboardConfig(s.app),
 		)
 		return
 	case views.ModeGameCompleted:
-		score, _ := internal.GetScoreService().GetCurrentScore(context.Background())
-		s.child = game.NewGameCompletedModel(score)
+		score, _ := s.app.Scores.GetCurrentScore(context.Background())
+		s.app.Sessions.FinishCurrentSession()
+		s.child = game.NewGameCompletedModel(score.Value, keys.FromSettings(s.app.Settings), theme.FromApp(s.app))
+
+		return
+
+	case views.ModeProfile:
+		s.child = profile.NewProfileModel(profile.DefaultProfileConfig(s.app))
+		return
+
+	case views.ModeStats:
+		s.child = stats.NewStatsModel(stats.DefaultStatsConfig(s.app))
+		return
+
+	case views.ModeAchievements:
+		s.child = achievements.NewAchievementsModel(achievements.DefaultAchievementsConfig(s.app))
+		return
+
+	case views.ModeSettings:
+		s.child = settings.NewSettingsModel(settings.DefaultSettingsConfig(s.app))
+		return
 
+	case views.ModeContinue:
+		s.child = s.continueSavedGame()
 		return
 
 	case views.ModeMenu:
-		s.child = startMenu
+		s.child = menu.InitalModel(s.app)
 		return
 	default:
 
-		nextLevelConfig := NextLevelConfigFromMode(mode)
+		nextLevelConfig := NextLevelConfigFromMode(s.app, mode)
 		s.child = game.InitalGameModel(nextLevelConfig)
 		return
 	}
 }
 
-func NextLevelConfigFromMode(level views.Mode) game.GameStartConfig {
+// continueSavedGame restores the saved game, consuming the save file. If the
+// save cannot be read we fall back to the menu.
+func (s *SuperSnake) continueSavedGame() tea.Model {
+	saved, err := game.LoadSavedGame(s.app)
+	if err != nil {
+		return menu.InitalModel(s.app)
+	}
+
+	restored, err := game.RestoreGameModel(game.LevelGameConfig(s.app, saved.Level), saved)
+	if err != nil {
+		return menu.InitalModel(s.app)
+	}
+
+	game.DeleteSavedGame(s.app)
+	return restored
+}
+
+func NextLevelConfigFromMode(app *internal.App, level views.Mode) game.GameStartConfig {
 
 	switch level {
 	case views.ModeGame1:
-		return game.Level1GameConfig()
+		return game.Level1GameConfig(app)
 	case views.ModeGame2:
-		return game.Level2GameConfig()
+		return game.Level2GameConfig(app)
 	case views.ModeGame3:
-		return game.Level3GameConfig()
+		return game.Level3GameConfig(app)
 	case views.ModeGame4:
-		return game.Level4GameConfig()
+		return game.Level4GameConfig(app)
 	case views.ModeGame5:
-		return game.Level5GameConfig()
+		return game.Level5GameConfig(app)
 	default:
-		return game.Level1GameConfig()
+		return game.Level1GameConfig(app)
 	}
 }
 
@@ -84,14 +127,23 @@ func (s *SuperSnake) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c", "q":
+		// Keys are read from the settings on every press so a preset chosen
+		// on the settings screen applies straight away.
+		if key.Matches(msg, keys.FromSettings(s.app.Settings).Quit) {
+			if capturer, ok := s.child.(views.InputCapturer); ok && capturer.IsCapturingInput() && msg.Type == tea.KeyRunes {
+				break
+			}
+
+			// A game in progress saves first and quits once that is done.
+			if g, ok := s.child.(*game.GameModel); ok {
+				return s, g.Quit()
+			}
 
 			return s, tea.Quit
 		}
 	case views.SwitchModeMsg:
 		s.setChild(msg.Target)
-		return s, tea.ClearScreen
+		return s, tea.Batch(tea.ClearScreen, s.child.Init())
 	}
 
 	var cmd tea.Cmd
@@ -0,0 +1,112 @@
+// Package theme holds the named colour schemes and board glyphs every view
+// renders with. The themes themselves are defined in themes.json, which is
+// embedded in the binary.
+package theme
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/the-Jinxist/golang_snake_game/internal"
+)
+
+// DefaultTheme is used when the settings name a theme that does not exist.
+const DefaultTheme = "classic"
+
+//go:embed themes.json
+var themesFile []byte
+
+// Theme is one colour scheme. Colours are anything lipgloss.Color accepts;
+// an empty Title leaves the ASCII titles in the terminal's own colour.
+type Theme struct {
+	Name     string         `json:"name"`
+	Title    lipgloss.Color `json:"title"`
+	Accent   lipgloss.Color `json:"accent"`
+	OnAccent lipgloss.Color `json:"on_accent"`
+	Error    lipgloss.Color `json:"error"`
+	Muted    lipgloss.Color `json:"muted"`
+	Subtle   lipgloss.Color `json:"subtle"`
+	Text     lipgloss.Color `json:"text"`
+	Gold     lipgloss.Color `json:"gold"`
+	Locked   lipgloss.Color `json:"locked"`
+	Board    lipgloss.Color `json:"board"`
+	Snake    lipgloss.Color `json:"snake"`
+	Food     lipgloss.Color `json:"food"`
+	BigFish  lipgloss.Color `json:"big_fish"`
+
+	EmptyCell  string `json:"empty_cell"`
+	FilledCell string `json:"filled_cell"`
+	// PlainFood draws food as coloured filled cells instead of emoji, which
+	// would ignore the theme's colours.
+	PlainFood bool `json:"plain_food"`
+}
+
+// themes is parsed once from the embedded file. It ships with the binary, so
+// a file that does not parse is a build mistake rather than something a
+// player can cause.
+var themes = mustParse(themesFile)
+
+func mustParse(contents []byte) []Theme {
+	var file struct {
+		Themes []Theme `json:"themes"`
+	}
+
+	if err := json.Unmarshal(contents, &file); err != nil {
+		panic(fmt.Sprintf("theme: themes.json: %s", err))
+	}
+
+	return file.Themes
+}
+
+// Names lists the themes in the order the settings screen cycles them.
+func Names() []string {
+	names := make([]string, 0, len(themes))
+	for _, theme := range themes {
+		names = append(names, theme.Name)
+	}
+
+	return names
+}
+
+// Get returns the theme called name.
+func Get(name string) (Theme, bool) {
+	index := slices.IndexFunc(themes, func(theme Theme) bool { return theme.Name == name })
+	if index < 0 {
+		return Theme{}, false
+	}
+
+	return themes[index], true
+}
+
+// FromSettings returns the player's chosen theme, or the default one if the
+// settings name a theme that does not exist.
+func FromSettings(settings internal.Settings) Theme {
+	if theme, ok := Get(settings.Theme); ok {
+		return theme
+	}
+
+	theme, _ := Get(DefaultTheme)
+	return theme
+}
+
+// FromApp returns the theme app plays in: the one given with --theme for
+// this run, if any, or else the player's chosen one.
+func FromApp(app *internal.App) Theme {
+	if theme, ok := Get(app.ThemeOverride); ok {
+		return theme
+	}
+
+	return FromSettings(app.Settings)
+}
+
+// Banner renders one of the ASCII titles in the theme's title colour.
+func (t Theme) Banner(title string) string {
+	if t.Title == "" {
+		return title
+	}
+
+	return lipgloss.NewStyle().Foreground(t.Title).Render(title)
+}
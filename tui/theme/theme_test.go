@@ -0,0 +1,55 @@
+package theme_test
+
+import (
+	"testing"
+
+	"github.com/the-Jinxist/golang_snake_game/internal"
+	"github.com/the-Jinxist/golang_snake_game/tui/theme"
+)
+
+func TestThemes(t *testing.T) {
+	names := theme.Names()
+	if len(names) == 0 || names[0] != theme.DefaultTheme {
+		t.Fatalf("Names() = %v, want the default theme first", names)
+	}
+
+	for _, name := range names {
+		got, ok := theme.Get(name)
+		if !ok {
+			t.Fatalf("Get(%q) found nothing", name)
+		}
+
+		if got.EmptyCell == "" || got.FilledCell == "" {
+			t.Fatalf("theme %s has no board glyphs", name)
+		}
+	}
+
+	if _, ok := theme.Get("plaid"); ok {
+		t.Fatal("Get found a theme that does not exist")
+	}
+}
+
+func TestFromApp(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings string
+		override string
+		want     string
+	}{
+		{name: "chosen theme", settings: "neon", want: "neon"},
+		{name: "chosen theme that does not exist", settings: "plaid", want: theme.DefaultTheme},
+		{name: "--theme", settings: "neon", override: "monochrome", want: "monochrome"},
+		{name: "--theme that does not exist", settings: "neon", override: "plaid", want: "neon"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			app := &internal.App{Settings: internal.DefaultSettings(), ThemeOverride: test.override}
+			app.Settings.Theme = test.settings
+
+			if got := theme.FromApp(app); got.Name != test.want {
+				t.Fatalf("FromApp() = %s, want %s", got.Name, test.want)
+			}
+		})
+	}
+}
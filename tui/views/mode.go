@@ -17,6 +17,11 @@ const (
 	ModeLeaderboard
 	ModeGameOver
 	ModeGameCompleted
+	ModeContinue
+	ModeProfile
+	ModeStats
+	ModeAchievements
+	ModeSettings
 )
 
 func NextLevelModeFromCurrent(level int) Mode {
@@ -50,6 +55,12 @@ type SwitchModeMsg struct {
 
 type ExitGameMsg struct{}
 
+// InputCapturer is implemented by views that take free text input, so global
+// shortcuts such as q should be passed through to them instead.
+type InputCapturer interface {
+	IsCapturingInput() bool
+}
+
 func ClearScreen() tea.Cmd {
 	return func() tea.Msg {
 		return tea.ClearScreen()